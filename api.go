@@ -3,14 +3,40 @@ package sentinel
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"reflect"
 	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
 )
 
 // ErrNotStruct is returned when a non-struct type is passed to Try* functions.
 var ErrNotStruct = errors.New("sentinel: only struct types are supported")
 
+// NotStructError reports the offending type when a non-struct type is passed
+// to Try* functions. It unwraps to ErrNotStruct.
+type NotStructError struct {
+	Type reflect.Type
+}
+
+// Error implements the error interface.
+func (e *NotStructError) Error() string {
+	if e.Type == nil {
+		return fmt.Sprintf("%s: got nil", ErrNotStruct)
+	}
+	return fmt.Sprintf("%s: got %s (kind %s)", ErrNotStruct, e.Type, e.Type.Kind())
+}
+
+// Unwrap returns ErrNotStruct so errors.Is(err, ErrNotStruct) holds.
+func (e *NotStructError) Unwrap() error {
+	return ErrNotStruct
+}
+
+// ErrNotCached is returned when an operation requires a type that has not been cached.
+var ErrNotCached = errors.New("sentinel: type not found in cache")
+
 // Global singleton instance.
 var instance *Sentinel
 
@@ -21,13 +47,25 @@ func init() {
 		cache:          NewCache(),
 		registeredTags: make(map[string]bool),
 		modulePath:     detectModulePath(),
+		localModules:   detectLocalModules(),
 	}
 }
 
+// readBuildInfo is the build info source used for module detection.
+var readBuildInfo = debug.ReadBuildInfo
+
 // detectModulePath returns the module path from build info, or empty string if unavailable.
-func detectModulePath() string {
-	info, ok := debug.ReadBuildInfo()
-	if !ok {
+// Detection never panics: runtimes with partial build info support (such as
+// js/wasm and TinyGo) degrade to an empty path, which disables Scan recursion.
+func detectModulePath() (path string) {
+	defer func() {
+		if recover() != nil {
+			path = ""
+		}
+	}()
+
+	info, ok := readBuildInfo()
+	if !ok || info == nil {
 		return ""
 	}
 	return info.Main.Path
@@ -44,11 +82,80 @@ type Sentinel struct {
 	// Tag registry for custom tags
 	registeredTags map[string]bool
 
+	// Tag aliases: alias -> canonical tag name, guarded by tagMutex
+	tagAliases map[string]string
+
 	// Tag registry mutex
 	tagMutex sync.RWMutex
 
 	// Module path from build info (e.g., "github.com/user/repo")
 	modulePath string
+
+	// Dependencies built from local source, such as workspace modules
+	localModules []string
+
+	// Extraction options
+	configMutex sync.RWMutex
+
+	// Maximum number of fields extracted per type (0 = unlimited)
+	maxFields int
+
+	// Maximum number of relationships Scan follows from the root (0 = DefaultMaxDepth)
+	maxDepth int
+
+	// Marker interfaces by name
+	markers map[string]reflect.Type
+
+	// Virtual fields appended to types by FQDN
+	virtualFields map[string][]FieldMetadata
+
+	// Registered nullable wrapper types: FQDN -> underlying type
+	nullableTypes map[string]string
+
+	// Registered scalar types: FQDN -> schema format
+	scalarFormats map[string]string
+
+	// Registered enum types: FQDN -> allowed values
+	enums map[string][]any
+
+	// Additional module roots registered with SetModulePaths
+	modulePaths []string
+
+	// Module source directory and path for SetSourceComments ("" = disabled)
+	sourceRoot   string
+	sourceModule string
+
+	// Parsed doc comments by package path, guarded by sourceMutex
+	sourceDocs  map[string]packageDocs
+	sourceMutex sync.Mutex
+
+	// Whether join-table detection is enabled
+	joinDetection bool
+
+	// Whether promoted fields of embedded structs are listed in Fields
+	flattenEmbedded bool
+
+	// Whether unexported fields are listed in Fields
+	unexportedFields bool
+
+	// Codec tags registered with RegisterCodecTag, besides json
+	codecTags []string
+
+	// Whether github.com/google/uuid.UUID is a scalar
+	uuidSupport bool
+}
+
+// structType resolves t to the struct type sentinel extracts, dereferencing
+// pointer-to-struct types. Returns a *NotStructError for any other type.
+func structType(t reflect.Type) (reflect.Type, error) {
+	// Sentinel only supports struct types
+	if t != nil && t.Kind() != reflect.Struct {
+		if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			return t.Elem(), nil
+		}
+		return nil, &NotStructError{Type: t}
+	}
+	return t, nil
 }
 
 // Inspect returns comprehensive metadata for a type.
@@ -62,34 +169,66 @@ func Inspect[T any]() Metadata {
 }
 
 // TryInspect returns comprehensive metadata for a type.
-// Returns ErrNotStruct if T is not a struct type.
+// Returns a *NotStructError wrapping ErrNotStruct if T is not a struct type.
 func TryInspect[T any]() (Metadata, error) {
 	var zero T
-	t := reflect.TypeOf(zero)
+	return inspectType(reflect.TypeOf(zero))
+}
 
-	// Sentinel only supports struct types
-	if t != nil && t.Kind() != reflect.Struct {
-		if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
-			t = t.Elem()
-		} else {
-			return Metadata{}, ErrNotStruct
+// InspectValue returns comprehensive metadata for the dynamic type of v, for
+// callers that only hold a value as any. v may also be a reflect.Value.
+// Pointers are dereferenced, and the result is the same cached Metadata that
+// Inspect returns for the concrete type. Returns a *NotStructError wrapping
+// ErrNotStruct if v is nil or not a struct.
+func InspectValue(v any) (Metadata, error) {
+	var t reflect.Type
+	if rv, ok := v.(reflect.Value); ok {
+		if rv.IsValid() {
+			t = rv.Type()
 		}
+	} else {
+		t = reflect.TypeOf(v)
 	}
 
-	fqdn := getFQDN(t)
-
-	// Check cache first
-	if cached, exists := instance.cache.Get(fqdn); exists {
-		return cached, nil
+	if t == nil {
+		return Metadata{}, &NotStructError{}
 	}
+	return inspectType(t)
+}
 
-	// Extract metadata
-	metadata := instance.extractMetadata(t)
+// BatchInspect returns metadata for each of types, in input order, for callers
+// that gather types at runtime, such as from a registry. Each type goes through
+// the same cached extraction as Inspect, and pointer-to-struct types are
+// dereferenced. A nil or non-struct type leaves a zero Metadata in its slot
+// without stopping the rest; the returned error joins a *NotStructError for
+// every such slot with errors.Join, and is nil when all succeed.
+func BatchInspect(types ...reflect.Type) ([]Metadata, error) {
+	results := make([]Metadata, len(types))
+	var errs []error
+	for i, t := range types {
+		if t == nil {
+			errs = append(errs, &NotStructError{})
+			continue
+		}
+		metadata, err := inspectType(t)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results[i] = metadata
+	}
+	return results, errors.Join(errs...)
+}
 
-	// Store in cache
-	instance.cache.Set(fqdn, metadata)
+// inspectType extracts and caches metadata for a single type.
+func inspectType(t reflect.Type) (Metadata, error) {
+	t, err := structType(t)
+	if err != nil {
+		return Metadata{}, err
+	}
 
-	return metadata, nil
+	// Extraction consults and fills the cache
+	return instance.extractMetadata(t), nil
 }
 
 // Scan performs recursive inspection of a type and all related types within the same module.
@@ -107,29 +246,45 @@ func Scan[T any]() Metadata {
 // TryScan performs recursive inspection of a type and all related types within the same module.
 // Unlike TryInspect which only processes a single type, TryScan will follow relationships and
 // automatically inspect any related types that share the same module root.
-// Returns ErrNotStruct if T is not a struct type.
+// Returns a *NotStructError wrapping ErrNotStruct if T is not a struct type.
 func TryScan[T any]() (Metadata, error) {
 	var zero T
 	t := reflect.TypeOf(zero)
 
-	// Sentinel only supports struct types
-	if t != nil && t.Kind() != reflect.Struct {
-		if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
-			t = t.Elem()
-		} else {
-			return Metadata{}, ErrNotStruct
-		}
+	t, err := structType(t)
+	if err != nil {
+		return Metadata{}, err
 	}
 
-	// Use a visited map to prevent infinite loops from circular references
-	visited := make(map[string]bool)
-	instance.scanWithVisited(t, visited)
+	// Use a visited set to prevent infinite loops from circular references
+	instance.scanWithVisited(t, newVisitedSet())
 
 	// Return the metadata for the root type
 	metadata, _ := instance.cache.Get(getFQDN(t))
 	return metadata, nil
 }
 
+// ScanWithProgress performs the same recursive inspection as Scan, invoking fn
+// each time a type is newly extracted and cached during the walk with the
+// type's FQDN and the running total. Types already cached before the call are
+// not reported. fn is called synchronously on the scanning goroutine, outside
+// any sentinel locks.
+// Panics if T is not a struct type.
+func ScanWithProgress[T any](fn func(discovered string, total int)) Metadata {
+	var zero T
+	t, err := structType(reflect.TypeOf(zero))
+	if err != nil {
+		panic(err)
+	}
+
+	visited := newVisitedSet()
+	visited.progress = fn
+	instance.scanWithVisited(t, visited)
+
+	metadata, _ := instance.cache.Get(getFQDN(t))
+	return metadata
+}
+
 // Tag registers a struct tag to be extracted during metadata processing.
 // This can be called regardless of seal status.
 func Tag(tagName string) {
@@ -139,6 +294,63 @@ func Tag(tagName string) {
 	instance.registeredTags[tagName] = true
 }
 
+// SetTagAliases maps alias tag names to canonical ones, such as "description"
+// to "desc" or "valid" to "validate", so that code reading Tags works across
+// codebases with different conventions. A field's alias tag is stored in Tags
+// under the canonical name, which is extracted even if it is not registered.
+// The field's own canonical tag takes precedence over an alias, and when
+// several aliases share a canonical name the first in sorted order wins. The
+// alias is only kept under its own name if it is registered with Tag. Replaces any
+// previous aliases; pass nil to remove them. Only affects types extracted after
+// the call.
+func SetTagAliases(aliases map[string]string) {
+	instance.tagMutex.Lock()
+	defer instance.tagMutex.Unlock()
+
+	instance.tagAliases = maps.Clone(aliases)
+}
+
+// SetMaxFields caps the number of fields extracted per type.
+// Types with more exported fields have their Fields truncated to the first n,
+// with Metadata.Truncated set and the original count in TotalFieldCount.
+// Relationships are still extracted from every field. A value of 0 or less
+// removes the limit (the default). Only affects types extracted after the call.
+func SetMaxFields(n int) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+	instance.maxFields = n
+}
+
+// SetUnexportedFields enables or disables listing unexported fields in Fields,
+// which is off by default. This serves low-level tooling, such as debuggers and
+// gob-style encoders, that works with a struct's full layout. Unexported fields
+// carry their tags and have FieldMetadata.Exported set to false. They never
+// form relationships and are skipped by BuildReflectType. Only affects types
+// extracted after the call.
+func SetUnexportedFields(enabled bool) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.unexportedFields = enabled
+}
+
+// GetExamples returns the example tag value for each field of T that has one,
+// keyed by field name.
+// Panics if T is not a struct type.
+func GetExamples[T any]() map[string]string {
+	examples := make(map[string]string)
+	for _, field := range Inspect[T]().Fields {
+		if example, ok := field.Tags["example"]; ok {
+			examples[field.Name] = example
+		}
+	}
+	return examples
+}
+
 // Browse returns all type names that have been cached.
 func Browse() []string {
 	return instance.cache.Keys()
@@ -150,6 +362,28 @@ func Lookup(typeName string) (Metadata, bool) {
 	return instance.cache.Get(typeName)
 }
 
+// Forget removes a single type from the cache, so that the next Inspect or
+// Scan extracts it afresh. Types related to it stay cached. Forgetting a type
+// that is not cached is a no-op.
+func Forget(typeName string) {
+	instance.cache.Delete(typeName)
+}
+
+// Stats returns the global cache's hit, miss, store and eviction counters
+// along with the number of cached types. Lookups made by sentinel itself while
+// extracting count as well, so the hit ratio reflects all cache traffic.
+func Stats() CacheStats {
+	return instance.cache.Stats()
+}
+
+// ForEach calls fn for each cached type in unspecified order, stopping early if
+// fn returns false. Unlike Browse and Schema, nothing is copied up front, which
+// makes it the cheaper choice for large caches. Types cached concurrently are
+// not observed during iteration. fn must not call other sentinel functions.
+func ForEach(fn func(fqdn string, metadata Metadata) bool) {
+	instance.cache.ForEach(fn)
+}
+
 // Schema returns all cached metadata as a map.
 // This is useful for generating documentation, exporting schemas, or analyzing
 // the complete type graph of inspected types.
@@ -157,3 +391,84 @@ func Schema() map[string]Metadata {
 	return instance.cache.All()
 }
 
+// SetCacheTTL bounds how long extracted metadata stays cached, for services
+// that keep generating new types, such as through plugin reloads. Types older
+// than ttl are extracted afresh on their next Inspect or Scan, and are left out
+// of Browse, Schema and the other cache-wide views until then. Expired entries
+// are evicted on access, without a background goroutine. A value of 0 or less
+// keeps entries forever (the default). Reset restores the default.
+func SetCacheTTL(ttl time.Duration) {
+	instance.cache.SetTTL(ttl)
+}
+
+// TypesWithFieldType returns the FQDNs of all cached types that declare a field
+// of the given type. The type is matched against FieldMetadata.Type, so any field
+// type string is supported, including scalars and types from other packages
+// (e.g., "string", "*models.Address", "time.Time").
+func TypesWithFieldType(typeName string) []string {
+	var types []string
+
+	instance.cache.ForEach(func(fqdn string, metadata Metadata) bool {
+		for _, field := range metadata.Fields {
+			if field.Type == typeName {
+				types = append(types, fqdn)
+				break
+			}
+		}
+		return true
+	})
+
+	sort.Strings(types)
+	return types
+}
+
+// AmbiguousTypeNames returns simple type names shared by more than one cached type,
+// mapped to the sorted FQDNs that use them. Tools that key off TypeName rather
+// than FQDN can use this to detect collisions such as models.User and legacy.User.
+func AmbiguousTypeNames() map[string][]string {
+	byName := make(map[string][]string)
+	instance.cache.ForEach(func(fqdn string, metadata Metadata) bool {
+		byName[metadata.TypeName] = append(byName[metadata.TypeName], fqdn)
+		return true
+	})
+
+	ambiguous := make(map[string][]string)
+	for name, fqdns := range byName {
+		if len(fqdns) > 1 {
+			sort.Strings(fqdns)
+			ambiguous[name] = fqdns
+		}
+	}
+	return ambiguous
+}
+
+// TagUsageStats returns the number of cached fields carrying each tag.
+// Only extracted tags (common and registered) are counted.
+func TagUsageStats() map[string]int {
+	stats := make(map[string]int)
+	instance.cache.ForEach(func(_ string, metadata Metadata) bool {
+		for _, field := range metadata.Fields {
+			for tagName := range field.Tags {
+				stats[tagName]++
+			}
+		}
+		return true
+	})
+	return stats
+}
+
+// FieldsMissingTag returns the sorted "FQDN.Field" names of cached fields
+// that do not carry the given tag.
+func FieldsMissingTag(tag string) []string {
+	var missing []string
+	instance.cache.ForEach(func(fqdn string, metadata Metadata) bool {
+		for _, field := range metadata.Fields {
+			if _, ok := field.Tags[tag]; !ok {
+				missing = append(missing, fqdn+"."+field.Name)
+			}
+		}
+		return true
+	})
+	sort.Strings(missing)
+	return missing
+}
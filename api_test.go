@@ -3,6 +3,9 @@
 package sentinel
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -321,6 +324,53 @@ func TestTag(t *testing.T) {
 	})
 }
 
+func TestSetTagAliases(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Tag("valid")
+	SetTagAliases(map[string]string{
+		"description": "desc",
+		"valid":       "validate",
+		"label":       "title",
+		"caption":     "title",
+	})
+
+	type Aliased struct {
+		Name   string `description:"The display name" valid:"required"`
+		Email  string `desc:"Contact address" description:"ignored"`
+		Code   string `label:"Code" caption:"Short code"`
+		Status string `title:"State" label:"ignored"`
+	}
+
+	fields := nullableFields(Inspect[Aliased]().Fields)
+
+	if got := fields["Name"].Tags["desc"]; got != "The display name" {
+		t.Errorf("expected description alias under desc, got %q", got)
+	}
+	if _, ok := fields["Name"].Tags["description"]; ok {
+		t.Error("expected unregistered alias not to be kept under its own name")
+	}
+	if tags := fields["Name"].Tags; tags["validate"] != "required" || tags["valid"] != "required" {
+		t.Errorf("expected registered alias under both names, got %v", tags)
+	}
+	if got := fields["Email"].Tags["desc"]; got != "Contact address" {
+		t.Errorf("expected canonical tag to take precedence, got %q", got)
+	}
+	if got := fields["Code"].Tags["title"]; got != "Short code" {
+		t.Errorf("expected first alias in sorted order to win, got %q", got)
+	}
+	if got := fields["Status"].Tags["title"]; got != "State" {
+		t.Errorf("expected unregistered canonical tag to take precedence, got %q", got)
+	}
+
+	SetTagAliases(nil)
+	instance.cache.Clear()
+	if got := nullableFields(Inspect[Aliased]().Fields)["Name"].Tags["desc"]; got != "" {
+		t.Errorf("expected aliases to be removed, got %q", got)
+	}
+}
+
 func TestBrowse(t *testing.T) {
 	t.Run("browse registered types", func(t *testing.T) {
 		// Register some types and get their FQDNs
@@ -427,6 +477,51 @@ func TestLookup(t *testing.T) {
 	})
 }
 
+func TestForget(t *testing.T) {
+	type ForgetTestStruct struct {
+		Value string `json:"value"`
+	}
+
+	original := Inspect[ForgetTestStruct]()
+	Forget(original.FQDN)
+
+	if _, found := Lookup(original.FQDN); found {
+		t.Error("expected type to be forgotten")
+	}
+
+	// Forgetting again is a no-op
+	Forget(original.FQDN)
+
+	// The next Inspect re-extracts the type
+	Inspect[ForgetTestStruct]()
+	if _, found := Lookup(original.FQDN); !found {
+		t.Error("expected type to be cached again after Inspect")
+	}
+}
+
+func TestStats(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type StatsTestStruct struct {
+		Value string `json:"value"`
+	}
+
+	metadata := Inspect[StatsTestStruct]()
+	before := Stats()
+
+	Inspect[StatsTestStruct]()
+	Lookup("NonExistentType")
+
+	after := Stats()
+	if after.Hits != before.Hits+1 || after.Misses != before.Misses+1 {
+		t.Errorf("expected one hit and one miss, got %+v then %+v", before, after)
+	}
+	if after.Entries != 1 || after.Stores != 1 {
+		t.Errorf("expected one stored entry for %s, got %+v", metadata.FQDN, after)
+	}
+}
+
 func TestSchema(t *testing.T) {
 	t.Run("returns all cached metadata", func(t *testing.T) {
 		// Ensure some types are inspected
@@ -499,6 +594,211 @@ func TestSchema(t *testing.T) {
 	})
 }
 
+func TestForEach(t *testing.T) {
+	instance.cache.Clear()
+
+	Inspect[SimpleStruct]()
+	Inspect[TestUser]()
+
+	t.Run("visits every cached type", func(t *testing.T) {
+		seen := make(map[string]bool)
+		ForEach(func(fqdn string, metadata Metadata) bool {
+			if fqdn != metadata.FQDN {
+				t.Errorf("expected key %s to match FQDN %s", fqdn, metadata.FQDN)
+			}
+			seen[fqdn] = true
+			return true
+		})
+
+		if len(seen) != len(Browse()) {
+			t.Errorf("expected %d types, got %d", len(Browse()), len(seen))
+		}
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		calls := 0
+		ForEach(func(string, Metadata) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+}
+
+func TestTypesWithFieldType(t *testing.T) {
+	instance.cache.Clear()
+
+	userMeta := Inspect[User]()
+	profileMeta := Inspect[Profile]()
+	addressMeta := Inspect[Address]()
+	settingsMeta := Inspect[Settings]()
+
+	t.Run("finds scalar field types", func(t *testing.T) {
+		types := TypesWithFieldType("string")
+
+		expected := map[string]bool{
+			userMeta.FQDN:     true,
+			profileMeta.FQDN:  true,
+			addressMeta.FQDN:  true,
+			settingsMeta.FQDN: true,
+		}
+		if len(types) != len(expected) {
+			t.Fatalf("expected %d types, got %d: %v", len(expected), len(types), types)
+		}
+		for _, fqdn := range types {
+			if !expected[fqdn] {
+				t.Errorf("unexpected type %s", fqdn)
+			}
+		}
+	})
+
+	t.Run("finds pointer field types", func(t *testing.T) {
+		types := TypesWithFieldType("*sentinel.Address")
+
+		if len(types) != 1 {
+			t.Fatalf("expected 1 type, got %d: %v", len(types), types)
+		}
+		if types[0] != profileMeta.FQDN {
+			t.Errorf("expected %s, got %s", profileMeta.FQDN, types[0])
+		}
+	})
+
+	t.Run("returns sorted results", func(t *testing.T) {
+		types := TypesWithFieldType("string")
+		for i := 1; i < len(types); i++ {
+			if types[i-1] > types[i] {
+				t.Errorf("results not sorted: %v", types)
+			}
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		if types := TypesWithFieldType("complex128"); len(types) != 0 {
+			t.Errorf("expected no types, got %v", types)
+		}
+	})
+}
+
+func TestAmbiguousTypeNames(t *testing.T) {
+	instance.cache.Clear()
+
+	userMeta := Inspect[User]()
+	Inspect[Profile]()
+
+	t.Run("no collisions", func(t *testing.T) {
+		if ambiguous := AmbiguousTypeNames(); len(ambiguous) != 0 {
+			t.Errorf("expected no ambiguous names, got %v", ambiguous)
+		}
+	})
+
+	t.Run("same name in different packages", func(t *testing.T) {
+		legacy := Metadata{
+			FQDN:        "example.com/legacy.User",
+			TypeName:    "User",
+			PackageName: "example.com/legacy",
+		}
+		instance.cache.Set(legacy.FQDN, legacy)
+
+		ambiguous := AmbiguousTypeNames()
+		if len(ambiguous) != 1 {
+			t.Fatalf("expected 1 ambiguous name, got %v", ambiguous)
+		}
+
+		fqdns := ambiguous["User"]
+		expected := []string{legacy.FQDN, userMeta.FQDN}
+		if len(fqdns) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, fqdns)
+		}
+		for i := range expected {
+			if fqdns[i] != expected[i] {
+				t.Errorf("expected %v, got %v", expected, fqdns)
+				break
+			}
+		}
+	})
+}
+
+func TestSetMaxFields(t *testing.T) {
+	instance.cache.Clear()
+	defer SetMaxFields(0)
+
+	type ManyFields struct {
+		A string
+		B string
+		C string
+		D string
+	}
+
+	SetMaxFields(2)
+	metadata := Inspect[ManyFields]()
+
+	if len(metadata.Fields) != 2 {
+		t.Errorf("expected 2 fields, got %d", len(metadata.Fields))
+	}
+	if !metadata.Truncated || metadata.TotalFieldCount != 4 {
+		t.Errorf("expected truncation from 4 fields, got Truncated=%v TotalFieldCount=%d", metadata.Truncated, metadata.TotalFieldCount)
+	}
+
+	SetMaxFields(-1)
+	if instance.maxFields != 0 {
+		t.Errorf("expected negative limit to disable truncation, got %d", instance.maxFields)
+	}
+}
+
+func TestSetUnexportedFields(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type WithPrivate struct {
+		Public  string   `json:"public"`
+		private string   `db:"private_col"` //nolint:unused // Verifies opt-in extraction
+		_       struct{} `owner:"team"`
+	}
+
+	t.Run("excluded by default", func(t *testing.T) {
+		fields := Inspect[WithPrivate]().Fields
+		if len(fields) != 1 || fields[0].Name != "Public" || !fields[0].Exported {
+			t.Errorf("expected only the exported field, got %+v", fields)
+		}
+	})
+
+	Reset()
+	SetUnexportedFields(true)
+
+	t.Run("included when enabled", func(t *testing.T) {
+		metadata := Inspect[WithPrivate]()
+		if len(metadata.Fields) != 2 {
+			t.Fatalf("expected 2 fields without the blank field, got %+v", metadata.Fields)
+		}
+
+		private := metadata.Fields[1]
+		if private.Name != "private" || private.Exported {
+			t.Errorf("expected unexported private field, got %+v", private)
+		}
+		if !private.JSONOmitted {
+			t.Error("expected unexported field to be omitted from JSON")
+		}
+		if private.Tags["db"] != "private_col" {
+			t.Errorf("expected tags to be read, got %+v", private.Tags)
+		}
+		if metadata.TypeTags["owner"] != "team" {
+			t.Errorf("expected blank field tags to stay type tags, got %v", metadata.TypeTags)
+		}
+	})
+
+	t.Run("skipped by BuildReflectType", func(t *testing.T) {
+		built, err := Inspect[WithPrivate]().BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if built.NumField() != 1 {
+			t.Errorf("expected only the exported field, got %d", built.NumField())
+		}
+	})
+}
+
 func TestEdgeCases(t *testing.T) {
 	t.Run("struct with no fields", func(t *testing.T) {
 		type EmptyStruct struct{}
@@ -565,3 +865,228 @@ func TestScanEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+// AliasedID is an alias of a non-struct type.
+type AliasedID = string
+
+// DefinedID is a defined non-struct type.
+type DefinedID int
+
+// inspectGeneric mimics generic caller code that forwards its type parameter.
+func inspectGeneric[T any]() error {
+	_, err := TryInspect[T]()
+	return err
+}
+
+func TestNotStructError(t *testing.T) {
+	tests := []struct {
+		run  func() error
+		name string
+		want string
+	}{
+		{name: "alias via generic", run: inspectGeneric[AliasedID], want: "got string (kind string)"},
+		{name: "defined type", run: inspectGeneric[DefinedID], want: "got sentinel.DefinedID (kind int)"},
+		{name: "pointer to scalar", run: inspectGeneric[*int], want: "got *int (kind ptr)"},
+		{name: "scan slice", run: func() error {
+			_, err := TryScan[[]TestUser]()
+			return err
+		}, want: "got []sentinel.TestUser (kind slice)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if !errors.Is(err, ErrNotStruct) {
+				t.Fatalf("expected ErrNotStruct, got %v", err)
+			}
+
+			var nse *NotStructError
+			if !errors.As(err, &nse) {
+				t.Fatalf("expected *NotStructError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected message to contain %q, got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+// ExampleTagged carries example tags for GetExamples.
+type ExampleTagged struct {
+	Email string `json:"email" example:"jane@example.com"`
+	Age   int    `json:"age" example:"42"`
+	Notes string `json:"notes"`
+}
+
+func TestGetExamples(t *testing.T) {
+	examples := GetExamples[ExampleTagged]()
+
+	if len(examples) != 2 {
+		t.Fatalf("expected 2 examples, got %d: %v", len(examples), examples)
+	}
+	if examples["Email"] != "jane@example.com" {
+		t.Errorf("expected Email example 'jane@example.com', got %q", examples["Email"])
+	}
+	if examples["Age"] != "42" {
+		t.Errorf("expected Age example '42', got %q", examples["Age"])
+	}
+	if _, ok := examples["Notes"]; ok {
+		t.Error("expected no example for untagged field")
+	}
+}
+
+func TestInspectValue(t *testing.T) {
+	setupSentinelForTest()
+
+	t.Run("matches Inspect", func(t *testing.T) {
+		var v any = TestUser{}
+		metadata, err := InspectValue(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := Inspect[TestUser]()
+		if metadata.FQDN != expected.FQDN || len(metadata.Fields) != len(expected.Fields) {
+			t.Errorf("expected %s with %d fields, got %s with %d", expected.FQDN, len(expected.Fields), metadata.FQDN, len(metadata.Fields))
+		}
+	})
+
+	t.Run("dereferences pointers", func(t *testing.T) {
+		metadata, err := InspectValue(&SimpleStruct{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if metadata.TypeName != "SimpleStruct" {
+			t.Errorf("expected SimpleStruct, got %s", metadata.TypeName)
+		}
+		if _, ok := Lookup(metadata.FQDN); !ok {
+			t.Error("expected metadata to be cached under the type's FQDN")
+		}
+	})
+
+	t.Run("accepts reflect.Value", func(t *testing.T) {
+		metadata, err := InspectValue(reflect.ValueOf(NestedStruct{}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if metadata.TypeName != "NestedStruct" {
+			t.Errorf("expected NestedStruct, got %s", metadata.TypeName)
+		}
+	})
+
+	t.Run("rejects nil and non-struct values", func(t *testing.T) {
+		for _, v := range []any{nil, reflect.Value{}, 42, []TestUser{}} {
+			_, err := InspectValue(v)
+			if !errors.Is(err, ErrNotStruct) {
+				t.Errorf("expected ErrNotStruct for %#v, got %v", v, err)
+			}
+		}
+
+		_, err := InspectValue(nil)
+		if err.Error() != "sentinel: only struct types are supported: got nil" {
+			t.Errorf("unexpected nil message: %q", err.Error())
+		}
+	})
+}
+
+func TestBatchInspect(t *testing.T) {
+	setupSentinelForTest()
+
+	t.Run("returns metadata in input order", func(t *testing.T) {
+		results, err := BatchInspect(reflect.TypeOf(SimpleStruct{}), reflect.TypeOf(&TestUser{}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 2 || results[0].TypeName != "SimpleStruct" || results[1].TypeName != "TestUser" {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+		if _, ok := Lookup(results[1].FQDN); !ok {
+			t.Error("expected metadata to be cached")
+		}
+	})
+
+	t.Run("collects failures without stopping", func(t *testing.T) {
+		results, err := BatchInspect(reflect.TypeOf(42), reflect.TypeOf(NestedStruct{}), nil)
+		if !errors.Is(err, ErrNotStruct) {
+			t.Fatalf("expected ErrNotStruct, got %v", err)
+		}
+		if len(results) != 3 || results[1].TypeName != "NestedStruct" {
+			t.Fatalf("expected the struct to be inspected, got %+v", results)
+		}
+		if results[0].FQDN != "" || results[2].FQDN != "" {
+			t.Errorf("expected zero metadata for failed slots, got %+v", results)
+		}
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok || len(joined.Unwrap()) != 2 {
+			t.Errorf("expected two joined errors, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "got int (kind int)") || !strings.Contains(err.Error(), "got nil") {
+			t.Errorf("expected each failure to be reported, got %q", err.Error())
+		}
+	})
+
+	t.Run("no types", func(t *testing.T) {
+		results, err := BatchInspect()
+		if err != nil || len(results) != 0 {
+			t.Errorf("expected empty results, got %v, %v", results, err)
+		}
+	})
+}
+
+func TestTagUsageStats(t *testing.T) {
+	setupSentinelForTest()
+	userMeta := Inspect[TestUser]()
+	simpleMeta := Inspect[SimpleStruct]()
+
+	t.Run("counts", func(t *testing.T) {
+		stats := TagUsageStats()
+
+		// TestUser: 6 exported fields, 5 with json; SimpleStruct: 1 with json
+		expected := map[string]int{"json": 6, "db": 2, "validate": 3, "encrypt": 1, "desc": 1}
+		for tag, count := range expected {
+			if stats[tag] != count {
+				t.Errorf("expected %d fields with %s, got %d", count, tag, stats[tag])
+			}
+		}
+	})
+
+	t.Run("missing tag", func(t *testing.T) {
+		missing := FieldsMissingTag("json")
+		expected := []string{userMeta.FQDN + ".Internal"}
+		if !reflect.DeepEqual(missing, expected) {
+			t.Errorf("expected %v, got %v", expected, missing)
+		}
+
+		missing = FieldsMissingTag("db")
+		if len(missing) != 5 {
+			t.Errorf("expected 5 fields without db, got %v", missing)
+		}
+		if missing[0] != simpleMeta.FQDN+".Value" {
+			t.Errorf("expected sorted output starting with SimpleStruct, got %v", missing)
+		}
+	})
+}
+
+func TestSetCacheTTL(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	now := time.Now()
+	instance.cache.now = func() time.Time { return now }
+	SetCacheTTL(time.Hour)
+
+	fqdn := Inspect[SimpleStruct]().FQDN
+	now = now.Add(2 * time.Hour)
+	if types := Browse(); len(types) != 0 {
+		t.Errorf("expected expired type to be left out of Browse, got %v", types)
+	}
+	if _, ok := Lookup(fqdn); ok {
+		t.Error("expected expired type to be missing from Lookup")
+	}
+
+	Inspect[SimpleStruct]()
+	if types := Browse(); len(types) != 1 || types[0] != fqdn {
+		t.Errorf("expected the expired type to be extracted afresh, got %v", types)
+	}
+}
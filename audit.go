@@ -0,0 +1,36 @@
+package sentinel
+
+import "sort"
+
+// AuditReport summarizes configuration that had no effect on the cached types.
+type AuditReport struct {
+	UnusedTags []string `json:"unused_tags"` // Registered tags that appear on no cached field
+}
+
+// PostScanAudit reports registered tags that no cached field carries.
+// Run it after warm-up, once Scan or Inspect has populated the cache;
+// before then every registered tag is reported as unused.
+func PostScanAudit() AuditReport {
+	instance.tagMutex.RLock()
+	unused := make(map[string]bool, len(instance.registeredTags))
+	for tagName := range instance.registeredTags {
+		unused[tagName] = true
+	}
+	instance.tagMutex.RUnlock()
+
+	instance.cache.ForEach(func(_ string, metadata Metadata) bool {
+		for _, field := range metadata.Fields {
+			for tagName := range field.Tags {
+				delete(unused, tagName)
+			}
+		}
+		return len(unused) > 0
+	})
+
+	report := AuditReport{UnusedTags: make([]string, 0, len(unused))}
+	for tagName := range unused {
+		report.UnusedTags = append(report.UnusedTags, tagName)
+	}
+	sort.Strings(report.UnusedTags)
+	return report
+}
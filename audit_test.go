@@ -0,0 +1,39 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type AuditedUser struct {
+	Email string `json:"email" pii:"true"`
+	Name  string `json:"name"`
+}
+
+func TestPostScanAudit(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Tag("pii")
+	Tag("graphql")
+
+	t.Run("before warm-up", func(t *testing.T) {
+		report := PostScanAudit()
+		expected := []string{"graphql", "pii"}
+		if !reflect.DeepEqual(report.UnusedTags, expected) {
+			t.Errorf("expected UnusedTags %v, got %v", expected, report.UnusedTags)
+		}
+	})
+
+	t.Run("after warm-up", func(t *testing.T) {
+		Inspect[AuditedUser]()
+
+		report := PostScanAudit()
+		expected := []string{"graphql"}
+		if !reflect.DeepEqual(report.UnusedTags, expected) {
+			t.Errorf("expected UnusedTags %v, got %v", expected, report.UnusedTags)
+		}
+	})
+}
@@ -2,28 +2,86 @@ package sentinel
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// Cache stores extracted metadata permanently.
-// Since types are immutable at runtime, entries never expire.
+// Cache stores extracted metadata. Since types are immutable at runtime,
+// entries never expire by default; SetTTL bounds their lifetime for services
+// that keep generating new types. Expired entries are evicted lazily on Get,
+// so no background goroutine is involved.
 type Cache struct {
-	store map[string]Metadata
-	mu    sync.RWMutex
+	store     map[string]Metadata
+	storedAt  map[string]time.Time
+	now       func() time.Time
+	ttl       time.Duration
+	mu        sync.RWMutex
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	stores    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// CacheStats is a snapshot of a cache's lifetime counters.
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`      // Get calls that found an entry
+	Misses    uint64 `json:"misses"`    // Get calls that found nothing
+	Stores    uint64 `json:"stores"`    // Set calls, including overwrites
+	Evictions uint64 `json:"evictions"` // Entries removed by Delete, Clear or expiry
+	Entries   int    `json:"entries"`   // Unexpired entries currently cached
 }
 
 // NewCache creates a new cache.
 func NewCache() *Cache {
 	return &Cache{
-		store: make(map[string]Metadata),
+		store:    make(map[string]Metadata),
+		storedAt: make(map[string]time.Time),
+		now:      time.Now,
 	}
 }
 
-// Get retrieves metadata from the cache.
+// SetTTL sets how long entries stay valid after they are stored. Entries
+// older than ttl are treated as missing and evicted on their next Get. A value
+// of 0 or less disables expiry (the default). The TTL applies to entries
+// already cached, measured from when they were stored.
+func (c *Cache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = max(ttl, 0)
+}
+
+// expired reports whether an entry has outlived the TTL. The caller must hold
+// the lock.
+func (c *Cache) expired(typeName string, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(c.storedAt[typeName]) >= c.ttl
+}
+
+// Get retrieves metadata from the cache. Expired entries are evicted and
+// reported as missing.
 func (c *Cache) Get(typeName string) (Metadata, bool) {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	metadata, exists := c.store[typeName]
+	stale := exists && c.expired(typeName, c.now())
+	c.mu.RUnlock()
+
+	if stale {
+		c.mu.Lock()
+		// The entry may have been stored again since the read lock was released
+		if _, ok := c.store[typeName]; ok && c.expired(typeName, c.now()) {
+			delete(c.store, typeName)
+			delete(c.storedAt, typeName)
+			c.evictions.Add(1)
+		}
+		c.mu.Unlock()
+		metadata, exists = Metadata{}, false
+	}
+
+	if exists {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
 	return metadata, exists
 }
 
@@ -33,45 +91,130 @@ func (c *Cache) Set(typeName string, metadata Metadata) {
 	defer c.mu.Unlock()
 
 	c.store[typeName] = metadata
+	c.storedAt[typeName] = c.now()
+	c.stores.Add(1)
+}
+
+// Update replaces an entry with fn's result, holding the write lock so that
+// no Set or other Update can interleave between reading and writing it. The
+// entry keeps its original storage time for the TTL, and the update is not
+// counted in Stores. Returns false, without
+// calling fn, if the entry is missing or expired. fn must not call back into
+// the cache.
+func (c *Cache) Update(typeName string, fn func(Metadata) Metadata) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	metadata, exists := c.store[typeName]
+	if !exists || c.expired(typeName, c.now()) {
+		return false
+	}
+	c.store[typeName] = fn(metadata)
+	return true
+}
+
+// Delete removes a single entry from the cache.
+// Deleting a type that is not cached is a no-op.
+func (c *Cache) Delete(typeName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.store[typeName]; exists {
+		delete(c.store, typeName)
+		delete(c.storedAt, typeName)
+		c.evictions.Add(1)
+	}
 }
 
 // Clear removes all entries from the cache.
 // This should only be used in tests.
+// Statistics are kept, with every removed entry counted as an eviction.
 func (c *Cache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.evictions.Add(uint64(len(c.store)))
 	c.store = make(map[string]Metadata)
+	c.storedAt = make(map[string]time.Time)
+}
+
+// Stats returns the cache's lifetime counters and current entry count.
+// Counters are read individually, so a snapshot taken during concurrent use
+// may be mid-update by one operation.
+func (c *Cache) Stats() CacheStats {
+	entries := c.Size()
+
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Stores:    c.stores.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+	}
 }
 
-// Size returns the number of cached entries.
+// Size returns the number of unexpired cached entries.
 func (c *Cache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	return len(c.store)
+	if c.ttl == 0 {
+		return len(c.store)
+	}
+	now, size := c.now(), 0
+	for key := range c.store {
+		if !c.expired(key, now) {
+			size++
+		}
+	}
+	return size
 }
 
-// Keys returns all cached type names.
+// Keys returns all unexpired cached type names.
 func (c *Cache) Keys() []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	now := c.now()
 	keys := make([]string, 0, len(c.store))
 	for key := range c.store {
-		keys = append(keys, key)
+		if !c.expired(key, now) {
+			keys = append(keys, key)
+		}
 	}
 	return keys
 }
 
-// All returns a copy of all cached metadata.
+// ForEach calls fn for each unexpired entry in unspecified order, stopping
+// early if fn returns false. Entries are not copied up front: iteration holds
+// the read lock, so concurrent Sets wait until it finishes and are never
+// observed mid-iteration. fn must not call back into the cache.
+func (c *Cache) ForEach(fn func(typeName string, metadata Metadata) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+	for key, metadata := range c.store {
+		if c.expired(key, now) {
+			continue
+		}
+		if !fn(key, metadata) {
+			return
+		}
+	}
+}
+
+// All returns a copy of all unexpired cached metadata.
 func (c *Cache) All() map[string]Metadata {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	now := c.now()
 	result := make(map[string]Metadata, len(c.store))
 	for k, v := range c.store {
-		result[k] = v
+		if !c.expired(k, now) {
+			result[k] = v
+		}
 	}
 	return result
 }
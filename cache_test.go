@@ -3,6 +3,7 @@ package sentinel
 import (
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestCache(t *testing.T) {
@@ -76,6 +77,41 @@ func TestCache(t *testing.T) {
 		}
 	})
 
+	t.Run("ForEach method", func(t *testing.T) {
+		cache := NewCache()
+		cache.Set("Type1", Metadata{TypeName: "Type1"})
+		cache.Set("Type2", Metadata{TypeName: "Type2"})
+		cache.Set("Type3", Metadata{TypeName: "Type3"})
+
+		seen := make(map[string]string)
+		cache.ForEach(func(typeName string, metadata Metadata) bool {
+			seen[typeName] = metadata.TypeName
+			return true
+		})
+		if len(seen) != 3 {
+			t.Errorf("expected 3 entries, got %d", len(seen))
+		}
+		for key, name := range seen {
+			if key != name {
+				t.Errorf("expected metadata for %s, got %s", key, name)
+			}
+		}
+
+		calls := 0
+		cache.ForEach(func(string, Metadata) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Errorf("expected iteration to stop after 1 call, got %d", calls)
+		}
+
+		NewCache().ForEach(func(string, Metadata) bool {
+			t.Error("expected no calls on empty cache")
+			return true
+		})
+	})
+
 	t.Run("Clear method", func(t *testing.T) {
 		cache := NewCache()
 
@@ -103,6 +139,31 @@ func TestCache(t *testing.T) {
 		}
 	})
 
+	t.Run("Delete method", func(t *testing.T) {
+		cache := NewCache()
+
+		cache.Set("Type1", Metadata{TypeName: "Type1"})
+		cache.Set("Type2", Metadata{TypeName: "Type2"})
+
+		cache.Delete("Type1")
+
+		if size := cache.Size(); size != 1 {
+			t.Errorf("expected size 1 after delete, got %d", size)
+		}
+		if _, exists := cache.Get("Type1"); exists {
+			t.Error("expected Get to return false after Delete")
+		}
+		if _, exists := cache.Get("Type2"); !exists {
+			t.Error("expected other entries to survive Delete")
+		}
+
+		// Deleting a missing key is a no-op
+		cache.Delete("Missing")
+		if size := cache.Size(); size != 1 {
+			t.Errorf("expected size 1 after deleting missing key, got %d", size)
+		}
+	})
+
 	t.Run("overwrite existing entry", func(t *testing.T) {
 		cache := NewCache()
 
@@ -208,3 +269,198 @@ func TestCache(t *testing.T) {
 		// If we get here without deadlock/panic, concurrent access is safe
 	})
 }
+
+func TestCacheStats(t *testing.T) {
+	t.Run("counts operations", func(t *testing.T) {
+		cache := NewCache()
+
+		cache.Set("Type1", Metadata{TypeName: "Type1"})
+		cache.Set("Type2", Metadata{TypeName: "Type2"})
+		cache.Set("Type1", Metadata{TypeName: "Type1"})
+		cache.Get("Type1")
+		cache.Get("Type2")
+		cache.Get("Missing")
+		cache.Delete("Type2")
+		cache.Delete("Missing")
+
+		expected := CacheStats{Hits: 2, Misses: 1, Stores: 3, Evictions: 1, Entries: 1}
+		if stats := cache.Stats(); stats != expected {
+			t.Errorf("expected %+v, got %+v", expected, stats)
+		}
+	})
+
+	t.Run("survives Clear", func(t *testing.T) {
+		cache := NewCache()
+
+		cache.Set("Type1", Metadata{})
+		cache.Set("Type2", Metadata{})
+		cache.Get("Type1")
+		cache.Clear()
+
+		expected := CacheStats{Hits: 1, Stores: 2, Evictions: 2}
+		if stats := cache.Stats(); stats != expected {
+			t.Errorf("expected %+v, got %+v", expected, stats)
+		}
+	})
+
+	t.Run("concurrent hits and misses", func(t *testing.T) {
+		cache := NewCache()
+		cache.Set("Present", Metadata{})
+
+		const workers, lookups = 16, 500
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < lookups; j++ {
+					cache.Get("Present")
+					cache.Get("Absent")
+				}
+			}()
+		}
+		wg.Wait()
+
+		stats := cache.Stats()
+		if stats.Hits != workers*lookups || stats.Misses != workers*lookups {
+			t.Errorf("expected %d hits and misses, got %+v", workers*lookups, stats)
+		}
+	})
+}
+
+func TestCacheUpdate(t *testing.T) {
+	t.Run("replaces existing entries", func(t *testing.T) {
+		cache := NewCache()
+		cache.Set("Type1", Metadata{TypeName: "Type1"})
+
+		if !cache.Update("Type1", func(m Metadata) Metadata {
+			m.Warnings = append(m.Warnings, "updated")
+			return m
+		}) {
+			t.Fatal("expected Update to report the entry")
+		}
+		if m, _ := cache.Get("Type1"); m.TypeName != "Type1" || len(m.Warnings) != 1 {
+			t.Errorf("expected updated entry, got %+v", m)
+		}
+		if stores := cache.Stats().Stores; stores != 1 {
+			t.Errorf("expected Update not to count as a store, got %d", stores)
+		}
+	})
+
+	t.Run("skips missing entries", func(t *testing.T) {
+		cache := NewCache()
+		called := false
+		if cache.Update("Missing", func(m Metadata) Metadata { called = true; return m }) || called {
+			t.Error("expected Update to skip a missing entry")
+		}
+		if cache.Size() != 0 {
+			t.Error("expected Update not to create an entry")
+		}
+	})
+
+	t.Run("no lost updates", func(t *testing.T) {
+		cache := NewCache()
+		cache.Set("Counter", Metadata{})
+
+		const workers = 32
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cache.Update("Counter", func(m Metadata) Metadata {
+					m.TotalFieldCount++
+					return m
+				})
+			}()
+		}
+		wg.Wait()
+
+		if m, _ := cache.Get("Counter"); m.TotalFieldCount != workers {
+			t.Errorf("expected %d updates, got %d", workers, m.TotalFieldCount)
+		}
+	})
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCache := func() *Cache {
+		cache := NewCache()
+		cache.now = func() time.Time { return now }
+		return cache
+	}
+
+	t.Run("entries never expire by default", func(t *testing.T) {
+		cache := newCache()
+		cache.Set("Type1", Metadata{})
+
+		now = now.Add(24 * time.Hour)
+		if _, exists := cache.Get("Type1"); !exists {
+			t.Error("expected entry to be kept without a TTL")
+		}
+	})
+
+	t.Run("expired entries are missing and evicted on Get", func(t *testing.T) {
+		cache := newCache()
+		cache.SetTTL(time.Minute)
+		cache.Set("Old", Metadata{})
+		now = now.Add(30 * time.Second)
+		cache.Set("New", Metadata{})
+		now = now.Add(45 * time.Second)
+
+		if size := cache.Size(); size != 1 {
+			t.Errorf("expected only the unexpired entry to count, got %d", size)
+		}
+		if keys := cache.Keys(); len(keys) != 1 || keys[0] != "New" {
+			t.Errorf("expected keys [New], got %v", keys)
+		}
+		if all := cache.All(); len(all) != 1 {
+			t.Errorf("expected All to skip expired entries, got %v", all)
+		}
+		visited := 0
+		cache.ForEach(func(string, Metadata) bool {
+			visited++
+			return true
+		})
+		if visited != 1 {
+			t.Errorf("expected ForEach to skip expired entries, visited %d", visited)
+		}
+
+		if _, exists := cache.Get("Old"); exists {
+			t.Error("expected expired entry to be missing")
+		}
+		if _, exists := cache.Get("New"); !exists {
+			t.Error("expected unexpired entry to be found")
+		}
+
+		expected := CacheStats{Hits: 1, Misses: 1, Stores: 2, Evictions: 1, Entries: 1}
+		if stats := cache.Stats(); stats != expected {
+			t.Errorf("expected %+v, got %+v", expected, stats)
+		}
+	})
+
+	t.Run("storing again restarts the TTL", func(t *testing.T) {
+		cache := newCache()
+		cache.SetTTL(time.Minute)
+		cache.Set("Type1", Metadata{})
+		now = now.Add(50 * time.Second)
+		cache.Set("Type1", Metadata{})
+		now = now.Add(50 * time.Second)
+
+		if _, exists := cache.Get("Type1"); !exists {
+			t.Error("expected refreshed entry to be found")
+		}
+	})
+
+	t.Run("disabling the TTL keeps entries", func(t *testing.T) {
+		cache := newCache()
+		cache.SetTTL(time.Minute)
+		cache.Set("Type1", Metadata{})
+		now = now.Add(2 * time.Minute)
+		cache.SetTTL(-1)
+
+		if _, exists := cache.Get("Type1"); !exists {
+			t.Error("expected entry to be kept once expiry is disabled")
+		}
+	})
+}
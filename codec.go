@@ -0,0 +1,68 @@
+package sentinel
+
+import (
+	"slices"
+	"strings"
+)
+
+// RegisterCodecTag declares a struct tag, such as xml, yaml, bson or msgpack,
+// as a serialization codec, and registers it for extraction like Tag. Each
+// field's SerializedNames then includes the name the codec writes it under:
+// the first element of the tag, falling back to the field name when the tag is
+// absent or has no name. Fields tagged "-" are left out for that codec, and
+// unexported fields for every codec. json is always a codec. Only affects
+// types extracted after the call.
+func RegisterCodecTag(name string) {
+	Tag(name)
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if name != "json" && !slices.Contains(instance.codecTags, name) {
+		instance.codecTags = append(instance.codecTags, name)
+	}
+}
+
+// codecs returns the codec tags: json followed by the registered ones.
+func (s *Sentinel) codecs() []string {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return append([]string{"json"}, s.codecTags...)
+}
+
+// serializedNames resolves the name of a field under each codec.
+func serializedNames(field FieldMetadata, codecs []string) map[string]string {
+	if !field.Exported {
+		return nil
+	}
+
+	names := make(map[string]string, len(codecs))
+	for _, codec := range codecs {
+		if name, included := codecFieldName(field, codec); included {
+			names[codec] = name
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// codecFieldName returns the name a field is serialized under by a codec,
+// following encoding/json conventions, which the common codecs share. Returns
+// false if the field is excluded with a "-" tag; "-," names the field "-".
+func codecFieldName(field FieldMetadata, codec string) (string, bool) {
+	tag, ok := field.Tags[codec]
+	if !ok {
+		return field.Name, true
+	}
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
@@ -0,0 +1,100 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type CodecDocument struct {
+	ID      string `json:"id" xml:"id,attr" yaml:"id"`
+	Title   string `json:"title,omitempty" xml:"name"`
+	Body    string `yaml:",omitempty"`
+	Secret  string `json:"-" xml:"-" yaml:"-"`
+	Dash    string `json:"-," yaml:"-"`
+	private string //nolint:unused // unexported fields are never serialized
+}
+
+func TestSerializedNames(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("json is always a codec", func(t *testing.T) {
+		field := Inspect[CodecDocument]().Fields[0]
+		if !reflect.DeepEqual(field.SerializedNames, map[string]string{"json": "id"}) {
+			t.Errorf("expected only json, got %v", field.SerializedNames)
+		}
+	})
+
+	Reset()
+	RegisterCodecTag("xml")
+	RegisterCodecTag("yaml")
+	RegisterCodecTag("yaml")
+
+	expected := map[string]map[string]string{
+		"ID":     {"json": "id", "xml": "id", "yaml": "id"},
+		"Title":  {"json": "title", "xml": "name", "yaml": "Title"},
+		"Body":   {"json": "Body", "xml": "Body", "yaml": "Body"},
+		"Secret": nil,
+		"Dash":   {"json": "-", "xml": "Dash"},
+	}
+
+	SetUnexportedFields(true)
+	expected["private"] = nil
+
+	metadata := Inspect[CodecDocument]()
+	if len(metadata.Fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(metadata.Fields))
+	}
+	for _, field := range metadata.Fields {
+		if !reflect.DeepEqual(field.SerializedNames, expected[field.Name]) {
+			t.Errorf("field %s: expected %v, got %v", field.Name, expected[field.Name], field.SerializedNames)
+		}
+	}
+
+	t.Run("codec tags are extracted", func(t *testing.T) {
+		if tags := metadata.Fields[0].Tags; tags["xml"] != "id,attr" || tags["yaml"] != "id" {
+			t.Errorf("expected codec tags in Tags, got %v", tags)
+		}
+	})
+
+	t.Run("virtual fields", func(t *testing.T) {
+		RegisterVirtualField[CodecDocument](FieldMetadata{
+			Name: "Slug",
+			Type: "string",
+			Kind: KindScalar,
+			Tags: map[string]string{"json": "slug", "xml": "-"},
+		})
+		fields := Inspect[CodecDocument]().Fields
+		slug := fields[len(fields)-1]
+		if want := map[string]string{"json": "slug", "yaml": "Slug"}; !reflect.DeepEqual(slug.SerializedNames, want) {
+			t.Errorf("expected %v, got %v", want, slug.SerializedNames)
+		}
+	})
+}
+
+func TestCodecFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    FieldMetadata
+		expected string
+		ok       bool
+	}{
+		{"tag name", FieldMetadata{Name: "ID", Tags: map[string]string{"json": "id"}}, "id", true},
+		{"tag with options", FieldMetadata{Name: "ID", Tags: map[string]string{"json": "id,omitempty"}}, "id", true},
+		{"options only", FieldMetadata{Name: "ID", Tags: map[string]string{"json": ",omitempty"}}, "ID", true},
+		{"no tag", FieldMetadata{Name: "ID"}, "ID", true},
+		{"excluded", FieldMetadata{Name: "ID", Tags: map[string]string{"json": "-"}}, "", false},
+		{"dash name", FieldMetadata{Name: "ID", Tags: map[string]string{"json": "-,"}}, "-", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := codecFieldName(tt.field, "json")
+			if name != tt.expected || ok != tt.ok {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, name, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package sentinel
+
+import (
+	_ "embed" // Embeds the compact schema definition
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CompactSchemaVersion is the format version written by ExportCompactSchema.
+// It is versioned independently of Metadata and SchemaDocumentVersion.
+const CompactSchemaVersion = "1"
+
+// compactSchemaDefinition is the JSON Schema describing the compact format.
+//
+//go:embed compact.schema.json
+var compactSchemaDefinition []byte
+
+// compactTags are the tags carried through to the compact format.
+var compactTags = []string{"json", "validate", "db", "desc", "example"}
+
+// compactSchema is the root of the compact wire format.
+type compactSchema struct {
+	Version string        `json:"version"`
+	Types   []compactType `json:"types"`
+}
+
+// compactType is the compact shape of a cached type.
+type compactType struct {
+	FQDN          string                `json:"fqdn"`
+	Fields        []compactField        `json:"fields"`
+	Relationships []compactRelationship `json:"relationships"`
+}
+
+// compactField is the compact shape of a field.
+type compactField struct {
+	Tags     map[string]string `json:"tags,omitempty"`
+	Name     string            `json:"name"`
+	JSONName string            `json:"json_name"`
+	Type     string            `json:"type"`
+	Kind     FieldKind         `json:"kind"`
+	Required bool              `json:"required"`
+	Nullable bool              `json:"nullable"`
+}
+
+// compactRelationship is the compact shape of a relationship.
+type compactRelationship struct {
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// CompactSchemaDefinition returns the JSON Schema describing the output of ExportCompactSchema.
+func CompactSchemaDefinition() []byte {
+	return append([]byte(nil), compactSchemaDefinition...)
+}
+
+// ExportCompactSchema writes all cached types in a minimal, stable JSON format
+// intended for non-Go consumers. Each type carries its FQDN, its fields (name,
+// json_name, type, kind, required, nullable and a whitelist of tags) and its
+// relationships (to and kind only). The format is described by
+// CompactSchemaDefinition and only changes when CompactSchemaVersion does;
+// new Metadata fields do not appear unless explicitly mapped.
+//
+// json_name is empty for fields encoding/json skips (see JSONOmitted). required
+// is set when the validate tag contains a "required" rule, and nullable is set
+// for pointer, slice, map and interface fields. Types are sorted by FQDN.
+func ExportCompactSchema(w io.Writer) error {
+	schema := instance.cache.All()
+
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	doc := compactSchema{
+		Version: CompactSchemaVersion,
+		Types:   make([]compactType, 0, len(fqdns)),
+	}
+	for _, fqdn := range fqdns {
+		doc.Types = append(doc.Types, newCompactType(schema[fqdn]))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("sentinel: export compact schema: %w", err)
+	}
+	return nil
+}
+
+// newCompactType maps metadata onto the compact type shape.
+func newCompactType(metadata Metadata) compactType {
+	ct := compactType{
+		FQDN:          metadata.FQDN,
+		Fields:        make([]compactField, 0, len(metadata.Fields)),
+		Relationships: make([]compactRelationship, 0, len(metadata.Relationships)),
+	}
+
+	for _, field := range metadata.Fields {
+		cf := compactField{
+			Name:     field.Name,
+			JSONName: field.JSONName,
+			Type:     field.Type,
+			Kind:     field.Kind,
+			Required: hasValidateRule(field, "required"),
+			Nullable: isNullableField(field),
+		}
+		for _, tag := range compactTags {
+			if value, ok := field.Tags[tag]; ok {
+				if cf.Tags == nil {
+					cf.Tags = make(map[string]string)
+				}
+				cf.Tags[tag] = value
+			}
+		}
+		ct.Fields = append(ct.Fields, cf)
+	}
+
+	for _, rel := range metadata.Relationships {
+		ct.Relationships = append(ct.Relationships, compactRelationship{To: rel.To, Kind: rel.Kind})
+	}
+
+	return ct
+}
+
+// hasValidateRule reports whether a field's validate tag contains the given rule.
+func hasValidateRule(field FieldMetadata, rule string) bool {
+	for _, r := range strings.Split(field.Tags["validate"], ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullableField reports whether a field's JSON value can be null.
+func isNullableField(field FieldMetadata) bool {
+	if field.Nullable {
+		return true
+	}
+	switch field.Kind {
+	case KindPointer, KindMap, KindInterface:
+		return true
+	case KindSlice:
+		// Arrays share KindSlice but are never null
+		return strings.HasPrefix(field.Type, "[]")
+	default:
+		return false
+	}
+}
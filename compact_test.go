@@ -0,0 +1,187 @@
+package sentinel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type CompactFixture struct {
+	Email   string            `json:"email" validate:"required,email" encrypt:"pii" desc:"Contact email"`
+	Nick    *string           `json:"nick,omitempty"`
+	Codes   []int             `json:"codes"`
+	Fixed   [2]int            `json:"fixed"`
+	Attrs   map[string]string `json:"attrs"`
+	Hidden  string            `json:"-"`
+	Profile *Profile          `json:"profile"`
+}
+
+// validateJSONSchema checks a decoded JSON value against the subset of JSON
+// Schema used by compact.schema.json: type, enum, required, properties,
+// additionalProperties, items and local $ref.
+func validateJSONSchema(root, schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		def, ok := root["$defs"].(map[string]any)[name].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: unresolved $ref %s", path, ref)
+		}
+		return validateJSONSchema(root, def, value, path)
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return fmt.Errorf("%s: %v not in enum %v", path, value, enum)
+	}
+
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		for i, item := range items {
+			if err := validateJSONSchema(root, itemSchema, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := object[key.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, key)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key, propValue := range object {
+			propSchema, ok := properties[key].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+				continue
+			}
+			if err := validateJSONSchema(root, propSchema, propValue, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestExportCompactSchema(t *testing.T) {
+	instance.cache.Clear()
+	Inspect[CompactFixture]()
+	Scan[User]()
+
+	var buf bytes.Buffer
+	if err := ExportCompactSchema(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("validates against definition", func(t *testing.T) {
+		var definition map[string]any
+		if err := json.Unmarshal(CompactSchemaDefinition(), &definition); err != nil {
+			t.Fatalf("invalid schema definition: %v", err)
+		}
+		var output any
+		if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+			t.Fatalf("invalid output: %v", err)
+		}
+		if err := validateJSONSchema(definition, definition, output, "$"); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var doc compactSchema
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+
+	t.Run("versioned and sorted", func(t *testing.T) {
+		if doc.Version != CompactSchemaVersion {
+			t.Errorf("expected version %s, got %s", CompactSchemaVersion, doc.Version)
+		}
+		if !slices.IsSortedFunc(doc.Types, func(a, b compactType) int { return strings.Compare(a.FQDN, b.FQDN) }) {
+			t.Error("expected types sorted by FQDN")
+		}
+	})
+
+	t.Run("field shape", func(t *testing.T) {
+		var fixture compactType
+		for _, ct := range doc.Types {
+			if strings.HasSuffix(ct.FQDN, ".CompactFixture") {
+				fixture = ct
+			}
+		}
+		fields := make(map[string]compactField)
+		for _, field := range fixture.Fields {
+			fields[field.Name] = field
+		}
+
+		email := fields["Email"]
+		if email.JSONName != "email" || !email.Required || email.Nullable {
+			t.Errorf("unexpected Email shape: %+v", email)
+		}
+		if _, ok := email.Tags["encrypt"]; ok {
+			t.Error("expected non-whitelisted tags to be dropped")
+		}
+		if email.Tags["desc"] != "Contact email" {
+			t.Errorf("expected desc tag, got %v", email.Tags)
+		}
+
+		nullable := map[string]bool{"Nick": true, "Codes": true, "Fixed": false, "Attrs": true, "Profile": true}
+		for name, expected := range nullable {
+			if fields[name].Nullable != expected {
+				t.Errorf("expected %s nullable=%v", name, expected)
+			}
+		}
+		if fields["Hidden"].JSONName != "" {
+			t.Errorf("expected empty json_name for excluded field, got %q", fields["Hidden"].JSONName)
+		}
+		if fields["Nick"].JSONName != "nick" {
+			t.Errorf("expected json options stripped, got %q", fields["Nick"].JSONName)
+		}
+	})
+
+	t.Run("relationships carry to and kind only", func(t *testing.T) {
+		for _, unmapped := range []string{`"to_package"`, `"field_groups"`, `"index"`} {
+			if strings.Contains(buf.String(), unmapped) {
+				t.Errorf("expected unmapped metadata %s to be absent", unmapped)
+			}
+		}
+		for _, ct := range doc.Types {
+			if strings.HasSuffix(ct.FQDN, ".User") && len(ct.Relationships) != 3 {
+				t.Errorf("expected 3 User relationships, got %d", len(ct.Relationships))
+			}
+		}
+	})
+
+	t.Run("unexported fields have no json_name", func(t *testing.T) {
+		SetUnexportedFields(true)
+		defer SetUnexportedFields(false)
+
+		type CompactSecret struct {
+			secret string //nolint:unused // Unexported fields are skipped by encoding/json
+		}
+		ct := newCompactType(Inspect[CompactSecret]())
+		if len(ct.Fields) != 1 || ct.Fields[0].JSONName != "" {
+			t.Errorf("expected an empty json_name for the unexported field, got %+v", ct.Fields)
+		}
+	})
+}
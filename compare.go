@@ -0,0 +1,125 @@
+package sentinel
+
+import "reflect"
+
+// TypeComparison describes the differences between the field sets of two types.
+// Fields are matched by their JSON name.
+type TypeComparison struct {
+	TypeMismatches map[string][2]string `json:"type_mismatches,omitempty"` // JSON name -> [A type, B type]
+	OnlyInA        []string             `json:"only_in_a,omitempty"`
+	OnlyInB        []string             `json:"only_in_b,omitempty"`
+	Common         []string             `json:"common,omitempty"`
+}
+
+// CompareTypes compares the fields of two types by JSON name, ignoring fields
+// encoding/json skips (see JSONOmitted). Fields present in both types with
+// different Go types are reported in TypeMismatches as well as in Common.
+// Panics if A or B is not a struct type.
+func CompareTypes[A any, B any]() TypeComparison {
+	a := Inspect[A]()
+	b := Inspect[B]()
+
+	bFields := make(map[string]FieldMetadata, len(b.Fields))
+	for _, field := range b.Fields {
+		if !field.JSONOmitted {
+			bFields[field.JSONName] = field
+		}
+	}
+
+	comparison := TypeComparison{
+		TypeMismatches: make(map[string][2]string),
+	}
+
+	seen := make(map[string]bool, len(a.Fields))
+	for _, field := range a.Fields {
+		if field.JSONOmitted {
+			continue
+		}
+		name := field.JSONName
+		seen[name] = true
+
+		other, exists := bFields[name]
+		if !exists {
+			comparison.OnlyInA = append(comparison.OnlyInA, name)
+			continue
+		}
+
+		comparison.Common = append(comparison.Common, name)
+		if field.Type != other.Type {
+			comparison.TypeMismatches[name] = [2]string{field.Type, other.Type}
+		}
+	}
+
+	for _, field := range b.Fields {
+		if !field.JSONOmitted && !seen[field.JSONName] {
+			comparison.OnlyInB = append(comparison.OnlyInB, field.JSONName)
+		}
+	}
+
+	return comparison
+}
+
+// StructurallyCompatible reports whether two types have the same field shape,
+// ignoring field names and tags. Every field the structs declare is compared by
+// position, whether exported or not, so the result does not depend on
+// extraction settings such as SetMaxFields or SetFlattenEmbedded, and virtual
+// fields play no part. Both types must have the same number of fields, and each
+// pair must share its underlying type shape (scalar kind, element, key and
+// array length, and the fields of nested structs, compared recursively).
+// Panics if A or B is not a struct type.
+func StructurallyCompatible[A any, B any]() bool {
+	var zeroA A
+	a, err := structType(reflect.TypeOf(zeroA))
+	if err != nil {
+		panic(err)
+	}
+	var zeroB B
+	b, err := structType(reflect.TypeOf(zeroB))
+	if err != nil {
+		panic(err)
+	}
+
+	return sameShape(a, b, make(map[[2]reflect.Type]bool))
+}
+
+// sameShape reports whether two types share the same underlying shape.
+// Pairs already under comparison are assumed compatible to terminate recursion.
+func sameShape(a, b reflect.Type, seen map[[2]reflect.Type]bool) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil || a.Kind() != b.Kind() {
+		return false
+	}
+
+	pair := [2]reflect.Type{a, b}
+	if seen[pair] {
+		return true
+	}
+	seen[pair] = true
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		return sameShape(a.Elem(), b.Elem(), seen)
+	case reflect.Array:
+		return a.Len() == b.Len() && sameShape(a.Elem(), b.Elem(), seen)
+	case reflect.Map:
+		return sameShape(a.Key(), b.Key(), seen) && sameShape(a.Elem(), b.Elem(), seen)
+	case reflect.Struct:
+		if a.NumField() != b.NumField() {
+			return false
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !sameShape(a.Field(i).Type, b.Field(i).Type, seen) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface, reflect.Func, reflect.Chan:
+		// Distinct interface, func and channel types are not compatible
+		return false
+	default:
+		// Scalars compare by kind
+		return true
+	}
+}
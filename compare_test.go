@@ -0,0 +1,179 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"testing"
+)
+
+type CompareModel struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Password  string `json:"-"`
+	Age       int    `json:"age"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type CompareDTO struct {
+	ID       string `json:"id"`
+	Email    string `json:"email,omitempty"`
+	Age      string `json:"age"`
+	Nickname string
+}
+
+func TestCompareTypes(t *testing.T) {
+	instance.cache.Clear()
+
+	comparison := CompareTypes[CompareModel, CompareDTO]()
+
+	t.Run("fields only in A", func(t *testing.T) {
+		if len(comparison.OnlyInA) != 1 || comparison.OnlyInA[0] != "created_at" {
+			t.Errorf("expected [created_at], got %v", comparison.OnlyInA)
+		}
+	})
+
+	t.Run("fields only in B", func(t *testing.T) {
+		if len(comparison.OnlyInB) != 1 || comparison.OnlyInB[0] != "Nickname" {
+			t.Errorf("expected [Nickname], got %v", comparison.OnlyInB)
+		}
+	})
+
+	t.Run("common fields", func(t *testing.T) {
+		expected := []string{"id", "email", "age"}
+		if len(comparison.Common) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, comparison.Common)
+		}
+		for i, name := range expected {
+			if comparison.Common[i] != name {
+				t.Errorf("expected common[%d] = %s, got %s", i, name, comparison.Common[i])
+			}
+		}
+	})
+
+	t.Run("type mismatches", func(t *testing.T) {
+		if len(comparison.TypeMismatches) != 1 {
+			t.Fatalf("expected 1 mismatch, got %v", comparison.TypeMismatches)
+		}
+		mismatch, ok := comparison.TypeMismatches["age"]
+		if !ok {
+			t.Fatal("expected mismatch for age")
+		}
+		if mismatch != [2]string{"int", "string"} {
+			t.Errorf("expected [int string], got %v", mismatch)
+		}
+	})
+
+	t.Run("excluded fields are ignored", func(t *testing.T) {
+		for _, name := range append(comparison.OnlyInA, comparison.Common...) {
+			if name == "Password" || name == "-" {
+				t.Errorf("expected json:\"-\" field to be ignored, got %s", name)
+			}
+		}
+	})
+
+	t.Run("unexported fields are ignored", func(t *testing.T) {
+		SetUnexportedFields(true)
+		defer SetUnexportedFields(false)
+
+		type CompareSecret struct {
+			A      int    `json:"a"`
+			secret string //nolint:unused // Unexported fields are skipped by encoding/json
+		}
+		type ComparePublic struct {
+			A int `json:"a"`
+		}
+		comparison := CompareTypes[CompareSecret, ComparePublic]()
+		if len(comparison.OnlyInA) != 0 || len(comparison.Common) != 1 {
+			t.Errorf("expected only the exported field to be compared, got %+v", comparison)
+		}
+	})
+}
+
+type ShapeInner struct {
+	Value int `json:"value"`
+}
+
+type ShapeInnerCopy struct {
+	Count int `db:"count"`
+}
+
+type ShapeA struct {
+	ID    string              `json:"id"`
+	Score float64             `json:"score"`
+	Items []ShapeInner        `json:"items"`
+	Index map[string]*float64 `json:"index"`
+	Grid  [3]int              `json:"grid"`
+}
+
+type ShapeB struct {
+	Key    string              `db:"key"`
+	Weight float64             `db:"weight"`
+	Rows   []ShapeInnerCopy    `db:"rows"`
+	Lookup map[string]*float64 `db:"lookup"`
+	Cells  [3]int              `db:"cells"`
+}
+
+type ShapeReordered struct {
+	Score float64             `json:"score"`
+	ID    string              `json:"id"`
+	Items []ShapeInner        `json:"items"`
+	Index map[string]*float64 `json:"index"`
+	Grid  [3]int              `json:"grid"`
+}
+
+type ShapeArrayLen struct {
+	ID    string              `json:"id"`
+	Score float64             `json:"score"`
+	Items []ShapeInner        `json:"items"`
+	Index map[string]*float64 `json:"index"`
+	Grid  [4]int              `json:"grid"`
+}
+
+type ShapeNode struct {
+	Next *ShapeNode `json:"next"`
+}
+
+type ShapeLink struct {
+	Following *ShapeLink `json:"following"`
+}
+
+func TestStructurallyCompatible(t *testing.T) {
+	if !StructurallyCompatible[ShapeA, ShapeB]() {
+		t.Error("expected identical shapes with different names and tags to be compatible")
+	}
+	if StructurallyCompatible[ShapeA, ShapeReordered]() {
+		t.Error("expected reordered fields to be incompatible")
+	}
+	if StructurallyCompatible[ShapeA, ShapeArrayLen]() {
+		t.Error("expected different array lengths to be incompatible")
+	}
+	if StructurallyCompatible[ShapeA, ShapeInner]() {
+		t.Error("expected different field counts to be incompatible")
+	}
+	if !StructurallyCompatible[ShapeNode, ShapeLink]() {
+		t.Error("expected self-referential shapes to be compatible")
+	}
+
+	t.Run("unexported fields count", func(t *testing.T) {
+		type WithSecret struct {
+			A      int
+			secret string //nolint:unused // Only the struct layout matters
+		}
+		type WithoutSecret struct {
+			A int
+		}
+		if StructurallyCompatible[WithSecret, WithoutSecret]() {
+			t.Error("expected an extra unexported field to be incompatible")
+		}
+	})
+
+	t.Run("truncated fields count", func(t *testing.T) {
+		instance.cache.Clear()
+		SetMaxFields(1)
+		defer SetMaxFields(0)
+
+		if StructurallyCompatible[ShapeA, ShapeArrayLen]() {
+			t.Error("expected fields past the SetMaxFields limit to be compared")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package sentinel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DefaultMaxDepth is the default limit on how far Scan follows relationships
+// from the root type.
+const DefaultMaxDepth = 100
+
+// SetMaxDepth caps how many relationships deep Scan follows from the root type.
+// Related types beyond the limit are not extracted; instead a warning naming
+// the skipped type is added to the root's Metadata.Warnings and the rest of the
+// graph is scanned as usual. This guards against pathological chains, such as
+// generated code with hundreds of levels of embedding. A value of 0 or less
+// restores DefaultMaxDepth. Only affects scans started after the call.
+func SetMaxDepth(n int) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+	instance.maxDepth = n
+}
+
+// depthLimit returns the configured maximum scan depth.
+func (s *Sentinel) depthLimit() int {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	if s.maxDepth == 0 {
+		return DefaultMaxDepth
+	}
+	return s.maxDepth
+}
+
+// depthExceededWarning describes a related type skipped by the depth limit.
+func depthExceededWarning(limit int, from reflect.Type, field string, to reflect.Type) string {
+	return fmt.Sprintf("max depth %d reached: %s (field %s of %s) was not scanned",
+		limit, getFQDN(to), field, getFQDN(from))
+}
+
+// attachWarnings merges the warnings collected during a scan into the cached
+// metadata of the root type, skipping warnings it already carries.
+func (s *Sentinel) attachWarnings(fqdn string, visited *visitedSet) {
+	warnings := visited.Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+
+	s.cache.Update(fqdn, func(metadata Metadata) Metadata {
+		metadata.Warnings = mergeWarnings(metadata.Warnings, warnings)
+		return metadata
+	})
+}
+
+// mergeWarnings returns the sorted union of two warning lists.
+func mergeWarnings(existing, added []string) []string {
+	seen := make(map[string]bool, len(existing)+len(added))
+	merged := make([]string, 0, len(existing)+len(added))
+	for _, warning := range append(append([]string(nil), existing...), added...) {
+		if !seen[warning] {
+			seen[warning] = true
+			merged = append(merged, warning)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
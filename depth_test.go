@@ -0,0 +1,113 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type DepthRoot struct {
+	Chain DepthLevel1
+	Side  *DepthSide
+}
+
+type DepthLevel1 struct {
+	DepthLevel2
+}
+
+type DepthLevel2 struct {
+	DepthLevel3
+}
+
+type DepthLevel3 struct {
+	DepthLevel4
+}
+
+type DepthLevel4 struct {
+	Leaf string
+}
+
+type DepthSide struct {
+	Name string
+}
+
+func TestMaxDepth(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("default depth scans the whole chain", func(t *testing.T) {
+		metadata := Scan[DepthRoot]()
+		if len(metadata.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", metadata.Warnings)
+		}
+		if _, ok := Lookup(getFQDN(reflect.TypeOf(DepthLevel4{}))); !ok {
+			t.Error("expected the end of the chain to be cached")
+		}
+	})
+
+	Reset()
+	SetMaxDepth(2)
+
+	t.Run("branch beyond the limit is skipped", func(t *testing.T) {
+		metadata := Scan[DepthRoot]()
+
+		for _, cached := range []string{
+			getFQDN(reflect.TypeOf(DepthLevel1{})),
+			getFQDN(reflect.TypeOf(DepthLevel2{})),
+			getFQDN(reflect.TypeOf(DepthSide{})),
+		} {
+			if _, ok := Lookup(cached); !ok {
+				t.Errorf("expected %s to be cached", cached)
+			}
+		}
+		for _, skipped := range []string{
+			getFQDN(reflect.TypeOf(DepthLevel3{})),
+			getFQDN(reflect.TypeOf(DepthLevel4{})),
+		} {
+			if _, ok := Lookup(skipped); ok {
+				t.Errorf("expected %s beyond the depth limit to be skipped", skipped)
+			}
+		}
+
+		if len(metadata.Warnings) != 1 {
+			t.Fatalf("expected 1 warning on the root, got %v", metadata.Warnings)
+		}
+		warning := metadata.Warnings[0]
+		if !strings.Contains(warning, "max depth 2") || !strings.Contains(warning, "DepthLevel3") {
+			t.Errorf("unexpected warning: %s", warning)
+		}
+	})
+
+	t.Run("rescanning does not duplicate warnings", func(t *testing.T) {
+		metadata := Scan[DepthRoot]()
+		if len(metadata.Warnings) != 1 {
+			t.Errorf("expected 1 warning after rescan, got %v", metadata.Warnings)
+		}
+	})
+
+	t.Run("related types carry no warnings", func(t *testing.T) {
+		metadata, _ := Lookup(getFQDN(reflect.TypeOf(DepthLevel2{})))
+		if len(metadata.Warnings) != 0 {
+			t.Errorf("expected warnings only on the root, got %v", metadata.Warnings)
+		}
+	})
+
+	t.Run("inspect is unaffected", func(t *testing.T) {
+		Reset()
+		SetMaxDepth(1)
+
+		metadata := Inspect[DepthLevel3]()
+		if len(metadata.Warnings) != 0 || len(metadata.Relationships) != 1 {
+			t.Errorf("expected plain inspection, got %+v", metadata)
+		}
+	})
+
+	t.Run("non-positive restores the default", func(t *testing.T) {
+		SetMaxDepth(-1)
+		if limit := instance.depthLimit(); limit != DefaultMaxDepth {
+			t.Errorf("expected %d, got %d", DefaultMaxDepth, limit)
+		}
+	})
+}
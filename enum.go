@@ -0,0 +1,90 @@
+package sentinel
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterEnum declares the allowed values of the named scalar type T, such as
+// a string or integer type with a const block:
+//
+//	sentinel.RegisterEnum[Status]("active", "inactive")
+//
+// Fields of type T or *T get EnumValues set to the values in registration
+// order, and GenerateJSONSchema restricts them with an enum array. Registering
+// T again replaces its values. Only affects types extracted after the call.
+// Panics if T is not a named bool, integer, float or string type.
+func RegisterEnum[T any](values ...T) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.PkgPath() == "" || enumValue(reflect.Zero(t)) == nil {
+		panic(fmt.Sprintf("sentinel: RegisterEnum requires a named scalar type, got %v", t))
+	}
+
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = enumValue(reflect.ValueOf(v))
+	}
+	fqdn := getFQDN(t)
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if instance.enums == nil {
+		instance.enums = make(map[string][]any)
+	}
+	instance.enums[fqdn] = enum
+}
+
+// enumValue returns the basic value underlying a scalar, so that it encodes
+// as its kind rather than through methods of the named type. Returns nil for
+// other kinds.
+func enumValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	default:
+		return nil
+	}
+}
+
+// enumValues returns the registered values of an enum type. The bool is false
+// if t is not a registered named type.
+func (s *Sentinel) enumValues(t reflect.Type) ([]any, bool) {
+	if t == nil || t.Kind() == reflect.Ptr || t.Name() == "" {
+		return nil, false
+	}
+	fqdn := getFQDN(t)
+
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	values, ok := s.enums[fqdn]
+	return values, ok
+}
+
+// enumStrings returns the registered values of an enum field's type, or of
+// its element type for pointers, formatted as strings.
+func (s *Sentinel) enumStrings(t reflect.Type) []string {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	values, ok := s.enumValues(t)
+	if !ok {
+		return nil
+	}
+
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = fmt.Sprint(v)
+	}
+	return strs
+}
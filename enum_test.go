@@ -0,0 +1,97 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type EnumStatus string
+
+type EnumPriority int
+
+// String would change fmt output; enum values use the underlying int.
+func (p EnumPriority) String() string { return "priority" }
+
+type EnumTicket struct {
+	Status   EnumStatus   `json:"status"`
+	Previous *EnumStatus  `json:"previous"`
+	History  []EnumStatus `json:"history"`
+	Priority EnumPriority `json:"priority"`
+	Owner    string       `json:"owner"`
+}
+
+func TestRegisterEnum(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterEnum[EnumStatus]("active", "inactive")
+	RegisterEnum[EnumPriority](1, 2, 3)
+
+	metadata := Inspect[EnumTicket]()
+	fields := nullableFields(metadata.Fields)
+
+	t.Run("fields carry enum values", func(t *testing.T) {
+		if got := fields["Status"].EnumValues; !reflect.DeepEqual(got, []string{"active", "inactive"}) {
+			t.Errorf("expected Status values, got %v", got)
+		}
+		if got := fields["Previous"].EnumValues; !reflect.DeepEqual(got, []string{"active", "inactive"}) {
+			t.Errorf("expected pointer to carry element values, got %v", got)
+		}
+		if got := fields["Priority"].EnumValues; !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+			t.Errorf("expected underlying ints, got %v", got)
+		}
+		if got := fields["History"].EnumValues; got != nil {
+			t.Errorf("expected slices to carry no values, got %v", got)
+		}
+		if got := fields["Owner"].EnumValues; got != nil {
+			t.Errorf("expected unregistered type to carry no values, got %v", got)
+		}
+	})
+
+	t.Run("json schema uses an enum", func(t *testing.T) {
+		g := &jsonSchemaGenerator{}
+
+		schema := g.valueSchema(reflect.TypeOf(EnumStatus("")))
+		if schema["type"] != "string" || !reflect.DeepEqual(schema["enum"], []any{"active", "inactive"}) {
+			t.Errorf("expected string enum, got %v", schema)
+		}
+
+		schema = g.valueSchema(reflect.TypeOf(EnumPriority(0)))
+		if schema["type"] != "integer" || !reflect.DeepEqual(schema["enum"], []any{int64(1), int64(2), int64(3)}) {
+			t.Errorf("expected integer enum, got %v", schema)
+		}
+
+		schema = g.valueSchema(reflect.TypeOf((*EnumStatus)(nil)))
+		if !reflect.DeepEqual(schema["enum"], []any{"active", "inactive", nil}) {
+			t.Errorf("expected nullable enum to allow null, got %v", schema)
+		}
+		if again := g.valueSchema(reflect.TypeOf(EnumStatus(""))); len(again["enum"].([]any)) != 2 {
+			t.Errorf("expected registered values to be left unchanged, got %v", again)
+		}
+	})
+
+	t.Run("registering again replaces values", func(t *testing.T) {
+		RegisterEnum[EnumStatus]("open")
+		if values, _ := instance.enumValues(reflect.TypeOf(EnumStatus(""))); !reflect.DeepEqual(values, []any{"open"}) {
+			t.Errorf("expected replaced values, got %v", values)
+		}
+	})
+
+	t.Run("unnamed or non-scalar types panic", func(t *testing.T) {
+		for name, register := range map[string]func(){
+			"string": func() { RegisterEnum[string]("a") },
+			"struct": func() { RegisterEnum[EnumTicket]() },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("expected RegisterEnum[%s] to panic", name)
+					}
+				}()
+				register()
+			}()
+		}
+	})
+}
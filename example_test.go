@@ -0,0 +1,94 @@
+package sentinel_test
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/zoobz-io/sentinel"
+)
+
+type Account struct {
+	ID    string `json:"id" db:"account_id"`
+	Email string `json:"email" validate:"required,email" graphql:"emailAddress"`
+}
+
+type Customer struct {
+	Billing *Invoice  `json:"billing"`
+	History []Invoice `json:"history"`
+	ID      string    `json:"id"`
+}
+
+type Invoice struct {
+	Lines []InvoiceLine `json:"lines"`
+	Total int           `json:"total"`
+}
+
+type InvoiceLine struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+func ExampleInspect() {
+	sentinel.Tag("graphql")
+
+	metadata := sentinel.Inspect[Account]()
+	for _, field := range metadata.Fields {
+		fmt.Printf("%s json=%s graphql=%q\n", field.Name, field.Tags["json"], field.Tags["graphql"])
+	}
+	// Output:
+	// ID json=id graphql=""
+	// Email json=email graphql="emailAddress"
+}
+
+func ExampleScan() {
+	sentinel.Scan[Customer]()
+
+	var names []string
+	for _, metadata := range sentinel.Schema() {
+		switch metadata.TypeName {
+		case "Customer", "Invoice", "InvoiceLine":
+			names = append(names, metadata.TypeName)
+		}
+	}
+	sort.Strings(names)
+	fmt.Println(names)
+	// Output: [Customer Invoice InvoiceLine]
+}
+
+func ExampleGetReferencedBy() {
+	sentinel.Scan[Customer]()
+
+	for _, rel := range sentinel.GetReferencedBy[Invoice]() {
+		fmt.Printf("%s (%s)\n", rel.Field, rel.Kind)
+	}
+	// Output:
+	// Billing (reference)
+	// History (collection)
+}
+
+func ExampleLoadOrder() {
+	root := sentinel.Scan[Customer]()
+
+	steps, err := sentinel.LoadOrder(root.FQDN)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, step := range steps {
+		metadata, _ := sentinel.Lookup(step.FQDN)
+		fmt.Println(step.Depth, metadata.TypeName)
+	}
+	// Output:
+	// 0 Customer
+	// 1 Invoice
+	// 2 InvoiceLine
+}
+
+func ExampleExportCompactSchema() {
+	sentinel.Inspect[InvoiceLine]()
+
+	if err := sentinel.ExportCompactSchema(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,388 @@
+package sentinel
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaDocumentVersion is the format version written by ExportSchemaDocument.
+const SchemaDocumentVersion = "1"
+
+// SchemaDocument is a versioned, self-describing export of the cache.
+// It carries all cached metadata along with the relationship adjacency so
+// consumers don't have to rebuild the graph from individual relationships.
+//
+//nolint:govet // Field order defines the JSON document layout
+type SchemaDocument struct {
+	Version     string              `json:"version"`
+	Module      string              `json:"module"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Types       map[string]Metadata `json:"types"`
+	Graph       SchemaGraph         `json:"graph"`
+}
+
+// SchemaGraph is the relationship adjacency of a schema document.
+// Both maps are keyed by FQDN and hold sorted, de-duplicated FQDNs.
+type SchemaGraph struct {
+	Outbound map[string][]string `json:"outbound"` // Type -> types it references
+	Inbound  map[string][]string `json:"inbound"`  // Type -> types that reference it
+}
+
+// ExportSchemaDocument writes all cached metadata as a versioned JSON document.
+// Output is deterministic for a given cache apart from the generation timestamp,
+// and is the same on every platform: the layout fields Size, Align and Offset
+// depend on the architecture and are left out.
+// The document is streamed type by type, so memory use stays bounded for very
+// large caches.
+func ExportSchemaDocument(w io.Writer) error {
+	return streamSchemaDocument(w, time.Now().UTC(), "")
+}
+
+// ExportSortedSchemaDocument writes the same document as ExportSchemaDocument
+// with each type's fields in a canonical order (see Metadata.SortedFields), so
+// that reordering fields in source does not change the output. The cache is
+// not modified.
+func ExportSortedSchemaDocument(w io.Writer, by FieldSort) error {
+	return streamSchemaDocument(w, time.Now().UTC(), by)
+}
+
+// ParseSchemaDocument reads a document written by ExportSchemaDocument.
+// Parsed metadata has no ReflectType since reflect types cannot be serialized.
+func ParseSchemaDocument(r io.Reader) (SchemaDocument, error) {
+	var doc SchemaDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return SchemaDocument{}, fmt.Errorf("sentinel: parse schema document: %w", err)
+	}
+	if doc.Version != SchemaDocumentVersion {
+		return SchemaDocument{}, fmt.Errorf("sentinel: unsupported schema document version %q", doc.Version)
+	}
+	return doc, nil
+}
+
+// catalogTags are the tags given their own column in the field catalog.
+var catalogTags = []string{"json", "validate", "db", "encrypt", "redact"}
+
+// ExportFieldCatalogCSV writes one CSV row per field across the whole cache,
+// with the columns FQDN, FieldName, JSONName, Type, Kind followed by one column
+// per catalog tag (json, validate, db, encrypt, redact). Rows are sorted by FQDN
+// then field order. JSONName is empty for fields that encoding/json skips (see
+// FieldMetadata.JSONOmitted).
+func ExportFieldCatalogCSV(w io.Writer) error {
+	// Snapshot once so entries expiring or forgotten meanwhile are not
+	// written as empty metadata
+	schema := instance.cache.All()
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	writer := csv.NewWriter(w)
+
+	header := append([]string{"FQDN", "FieldName", "JSONName", "Type", "Kind"}, catalogTags...)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("sentinel: export field catalog: %w", err)
+	}
+
+	for _, fqdn := range fqdns {
+		for _, field := range schema[fqdn].Fields {
+			jsonName := field.JSONName
+			if field.JSONOmitted {
+				jsonName = ""
+			}
+			row := []string{fqdn, field.Name, jsonName, field.Type, string(field.Kind)}
+			for _, tag := range catalogTags {
+				row = append(row, field.Tags[tag])
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("sentinel: export field catalog: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("sentinel: export field catalog: %w", err)
+	}
+	return nil
+}
+
+// graphMLNamespace is the XML namespace of GraphML documents.
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// graphML is the root element of a GraphML document.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// graphMLKey declares an attribute available on nodes or edges.
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+// graphMLGraph holds the nodes and edges of a GraphML document.
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+// graphMLNode is a type in the GraphML graph, identified by FQDN.
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+// graphMLEdge is a relationship in the GraphML graph.
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+// graphMLData is an attribute value on a node or edge.
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ExportGraphML renders the relationship graph of all cached types as GraphML,
+// for loading into graph tools such as Gephi or yEd. Nodes are identified by
+// FQDN and carry typeName and package attributes; edges carry field and kind.
+// Relationship targets that are not cached are included as nodes so every
+// edge resolves. Output is deterministic for a given cache.
+func ExportGraphML() ([]byte, error) {
+	schema := instance.cache.All()
+
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	doc := graphML{
+		Xmlns: graphMLNamespace,
+		Keys: []graphMLKey{
+			{ID: "typeName", For: "node", AttrName: "typeName", AttrType: "string"},
+			{ID: "package", For: "node", AttrName: "package", AttrType: "string"},
+			{ID: "field", For: "edge", AttrName: "field", AttrType: "string"},
+			{ID: "kind", For: "edge", AttrName: "kind", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: "sentinel", EdgeDefault: "directed"},
+	}
+
+	nodes := make(map[string]bool, len(fqdns))
+	addNode := func(fqdn, typeName, pkg string) {
+		if nodes[fqdn] {
+			return
+		}
+		nodes[fqdn] = true
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: fqdn,
+			Data: []graphMLData{
+				{Key: "typeName", Value: typeName},
+				{Key: "package", Value: pkg},
+			},
+		})
+	}
+
+	for _, fqdn := range fqdns {
+		metadata := schema[fqdn]
+		addNode(fqdn, metadata.TypeName, metadata.PackageName)
+	}
+
+	for _, fqdn := range fqdns {
+		for _, rel := range schema[fqdn].Relationships {
+			if _, cached := schema[rel.To]; !cached {
+				addNode(rel.To, strings.TrimPrefix(rel.To, rel.ToPackage+"."), rel.ToPackage)
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				ID:     fmt.Sprintf("e%d", len(doc.Graph.Edges)),
+				Source: rel.From,
+				Target: rel.To,
+				Data: []graphMLData{
+					{Key: "field", Value: rel.Field},
+					{Key: "kind", Value: rel.Kind},
+				},
+			})
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("sentinel: export graphml: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// streamSchemaDocument writes the schema document for the current cache without
+// materializing it. The cache is copied once with All, which shares each
+// type's field and relationship slices, so every section sees the same types
+// even if entries expire or are forgotten meanwhile. FQDNs are then sorted and
+// each type's metadata is encoded on its own, so peak memory is proportional
+// to one encoded Metadata plus the snapshot, key and adjacency slices. The
+// output matches encoding a SchemaDocument with two-space indentation. Fields
+// are sorted by the given order, or left in declaration order when by is "".
+func streamSchemaDocument(w io.Writer, generatedAt time.Time, by FieldSort) error {
+	schema := instance.cache.All()
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	// Inbound adjacency needs every relationship up front; it holds only FQDNs
+	inbound := make(map[string][]string, len(fqdns))
+	for _, fqdn := range fqdns {
+		inbound[fqdn] = []string{}
+	}
+	for fqdn, metadata := range schema {
+		for _, rel := range metadata.Relationships {
+			inbound[rel.To] = append(inbound[rel.To], fqdn)
+		}
+	}
+	inboundKeys := make([]string, 0, len(inbound))
+	for fqdn, sources := range inbound {
+		inbound[fqdn] = sortedUnique(sources)
+		inboundKeys = append(inboundKeys, fqdn)
+	}
+	sort.Strings(inboundKeys)
+
+	stream := &jsonStream{w: bufio.NewWriter(w)}
+	stream.raw("{\n  \"version\": ")
+	stream.value(SchemaDocumentVersion, "")
+	stream.raw(",\n  \"module\": ")
+	stream.value(instance.modulePath, "")
+	stream.raw(",\n  \"generated_at\": ")
+	stream.value(generatedAt, "")
+	stream.raw(",\n  \"types\": ")
+	stream.object("  ", fqdns, func(fqdn string) any {
+		metadata := schema[fqdn]
+		if by != "" {
+			metadata.Fields = metadata.SortedFields(by)
+		}
+		return withoutLayout(metadata)
+	})
+	stream.raw(",\n  \"graph\": {\n    \"outbound\": ")
+	stream.object("    ", fqdns, func(fqdn string) any {
+		targets := make([]string, 0, len(schema[fqdn].Relationships))
+		for _, rel := range schema[fqdn].Relationships {
+			targets = append(targets, rel.To)
+		}
+		return sortedUnique(targets)
+	})
+	stream.raw(",\n    \"inbound\": ")
+	stream.object("    ", inboundKeys, func(fqdn string) any {
+		return inbound[fqdn]
+	})
+	stream.raw("\n  }\n}\n")
+
+	if err := stream.flush(); err != nil {
+		return fmt.Errorf("sentinel: export schema document: %w", err)
+	}
+	return nil
+}
+
+// withoutLayout returns a copy of metadata with the architecture-dependent
+// Size, Align and field Offset cleared, so they are omitted from documents.
+func withoutLayout(metadata Metadata) Metadata {
+	metadata.Size, metadata.Align = 0, 0
+	metadata.Fields = slices.Clone(metadata.Fields)
+	for i := range metadata.Fields {
+		metadata.Fields[i].Offset = 0
+	}
+	return metadata
+}
+
+// jsonStream writes indented JSON incrementally, keeping the first error.
+// Values are encoded into a reused buffer by one encoder per line prefix.
+type jsonStream struct {
+	err      error
+	w        *bufio.Writer
+	encoders map[string]*json.Encoder
+	buf      bytes.Buffer
+}
+
+// raw writes literal JSON framing.
+func (s *jsonStream) raw(str string) {
+	if s.err == nil {
+		_, s.err = s.w.WriteString(str)
+	}
+}
+
+// value encodes v with two-space indentation, prefixing continuation lines.
+func (s *jsonStream) value(v any, prefix string) {
+	if s.err != nil {
+		return
+	}
+
+	encoder, ok := s.encoders[prefix]
+	if !ok {
+		if s.encoders == nil {
+			s.encoders = make(map[string]*json.Encoder)
+		}
+		encoder = json.NewEncoder(&s.buf)
+		encoder.SetIndent(prefix, "  ")
+		s.encoders[prefix] = encoder
+	}
+
+	s.buf.Reset()
+	if s.err = encoder.Encode(v); s.err != nil {
+		return
+	}
+	// Drop the newline Encode appends
+	_, s.err = s.w.Write(bytes.TrimSuffix(s.buf.Bytes(), []byte("\n")))
+}
+
+// object writes a JSON object whose entries are produced one at a time in key order.
+// indent is the indentation of the line holding the opening brace.
+func (s *jsonStream) object(indent string, keys []string, value func(key string) any) {
+	if len(keys) == 0 {
+		s.raw("{}")
+		return
+	}
+
+	s.raw("{\n")
+	for i, key := range keys {
+		s.raw(indent + "  ")
+		s.value(key, "")
+		s.raw(": ")
+		s.value(value(key), indent+"  ")
+		if i < len(keys)-1 {
+			s.raw(",")
+		}
+		s.raw("\n")
+	}
+	s.raw(indent + "}")
+}
+
+// flush writes any buffered output and returns the first error encountered.
+func (s *jsonStream) flush() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.w.Flush()
+}
+
+// sortedUnique sorts a slice of strings in place and removes duplicates.
+func sortedUnique(values []string) []string {
+	slices.Sort(values)
+	return slices.Compact(values)
+}
@@ -0,0 +1,447 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestExportSchemaDocument(t *testing.T) {
+	instance.cache.Clear()
+	userMeta := Scan[User]()
+
+	t.Run("golden", func(t *testing.T) {
+		var buf bytes.Buffer
+		generatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		if err := streamSchemaDocument(&buf, generatedAt, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		golden := filepath.Join("testdata", "schema_document.golden.json")
+		if *updateGolden {
+			if err := os.WriteFile(golden, buf.Bytes(), 0o600); err != nil {
+				t.Fatalf("failed to update golden file: %v", err)
+			}
+		}
+
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("failed to read golden file: %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("schema document does not match %s (run with -update to regenerate)", golden)
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		var first, second bytes.Buffer
+		generatedAt := time.Now()
+		if err := streamSchemaDocument(&first, generatedAt, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := streamSchemaDocument(&second, generatedAt, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(first.Bytes(), second.Bytes()) {
+			t.Error("expected identical output for the same cache")
+		}
+	})
+
+	t.Run("matches in-memory encoding", func(t *testing.T) {
+		generatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		var streamed, encoded bytes.Buffer
+		if err := streamSchemaDocument(&streamed, generatedAt, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := encodeSchemaDocument(&encoded, buildSchemaDocument(generatedAt)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(streamed.Bytes(), encoded.Bytes()) {
+			t.Errorf("streamed document differs from in-memory encoding:\n%s\nvs\n%s", streamed.String(), encoded.String())
+		}
+	})
+
+	t.Run("empty cache", func(t *testing.T) {
+		original := instance.cache
+		instance.cache = NewCache()
+		defer func() { instance.cache = original }()
+
+		var buf bytes.Buffer
+		if err := streamSchemaDocument(&buf, time.Now(), ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc, err := ParseSchemaDocument(&buf)
+		if err != nil {
+			t.Fatalf("expected valid document, got %v", err)
+		}
+		if len(doc.Types) != 0 {
+			t.Errorf("expected no types, got %d", len(doc.Types))
+		}
+	})
+
+	t.Run("graph adjacency", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportSchemaDocument(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc, err := ParseSchemaDocument(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		profileFQDN := getFQDN(reflect.TypeOf(Profile{}))
+		addressFQDN := getFQDN(reflect.TypeOf(Address{}))
+
+		outbound := doc.Graph.Outbound[userMeta.FQDN]
+		if len(outbound) != 3 {
+			t.Errorf("expected 3 outbound edges from User, got %v", outbound)
+		}
+		if inbound := doc.Graph.Inbound[profileFQDN]; len(inbound) != 1 || inbound[0] != userMeta.FQDN {
+			t.Errorf("expected Profile to be referenced by User, got %v", inbound)
+		}
+		if outbound := doc.Graph.Outbound[addressFQDN]; outbound == nil || len(outbound) != 0 {
+			t.Errorf("expected empty outbound list for Address, got %v", outbound)
+		}
+	})
+
+	t.Run("sorted fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportSortedSchemaDocument(&buf, ByName); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc, err := ParseSchemaDocument(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fields := doc.Types[userMeta.FQDN].Fields
+		for i := 1; i < len(fields); i++ {
+			if fields[i-1].Name > fields[i].Name {
+				t.Fatalf("expected fields sorted by name, got %s before %s", fields[i-1].Name, fields[i].Name)
+			}
+		}
+		if cached, _ := instance.cache.Get(userMeta.FQDN); !reflect.DeepEqual(cached.Fields, userMeta.Fields) {
+			t.Error("expected cached fields to keep declaration order")
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportSchemaDocument(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		doc, err := ParseSchemaDocument(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if doc.Version != SchemaDocumentVersion {
+			t.Errorf("expected version %s, got %s", SchemaDocumentVersion, doc.Version)
+		}
+		if doc.Module != instance.modulePath {
+			t.Errorf("expected module %s, got %s", instance.modulePath, doc.Module)
+		}
+		if doc.GeneratedAt.IsZero() {
+			t.Error("expected generated_at to be set")
+		}
+		if len(doc.Types) != instance.cache.Size() {
+			t.Errorf("expected %d types, got %d", instance.cache.Size(), len(doc.Types))
+		}
+
+		parsed, ok := doc.Types[userMeta.FQDN]
+		if !ok {
+			t.Fatalf("expected %s in parsed types", userMeta.FQDN)
+		}
+		if parsed.TypeName != userMeta.TypeName || len(parsed.Fields) != len(userMeta.Fields) {
+			t.Errorf("parsed metadata does not match: %+v", parsed)
+		}
+		if len(parsed.Relationships) != len(userMeta.Relationships) {
+			t.Errorf("expected %d relationships, got %d", len(userMeta.Relationships), len(parsed.Relationships))
+		}
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		_, err := ParseSchemaDocument(strings.NewReader(`{"version": "99"}`))
+		if err == nil {
+			t.Error("expected error for unsupported version")
+		}
+	})
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		_, err := ParseSchemaDocument(strings.NewReader(`{`))
+		if err == nil {
+			t.Error("expected error for invalid JSON")
+		}
+	})
+}
+
+func TestExportFieldCatalogCSV(t *testing.T) {
+	instance.cache.Clear()
+	userMeta := Inspect[TestUser]()
+	Inspect[SimpleStruct]()
+
+	var buf bytes.Buffer
+	if err := ExportFieldCatalogCSV(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	t.Run("header", func(t *testing.T) {
+		expected := []string{"FQDN", "FieldName", "JSONName", "Type", "Kind", "json", "validate", "db", "encrypt", "redact"}
+		if strings.Join(records[0], ",") != strings.Join(expected, ",") {
+			t.Errorf("expected header %v, got %v", expected, records[0])
+		}
+	})
+
+	t.Run("row per field", func(t *testing.T) {
+		expected := 1 + len(userMeta.Fields) + 1
+		if len(records) != expected {
+			t.Errorf("expected %d records, got %d", expected, len(records))
+		}
+	})
+
+	t.Run("known row", func(t *testing.T) {
+		expected := []string{userMeta.FQDN, "Email", "email", "string", "scalar", "email", "required,email", "", "pii", ""}
+		for _, record := range records[1:] {
+			if record[0] == userMeta.FQDN && record[1] == "Email" {
+				if strings.Join(record, "|") != strings.Join(expected, "|") {
+					t.Errorf("expected %v, got %v", expected, record)
+				}
+				return
+			}
+		}
+		t.Error("expected a row for TestUser.Email")
+	})
+
+	t.Run("sorted by FQDN then field order", func(t *testing.T) {
+		rows := records[1:]
+		for i := 1; i < len(rows); i++ {
+			if rows[i-1][0] > rows[i][0] {
+				t.Errorf("rows not sorted by FQDN at %d", i)
+			}
+		}
+		if rows[0][0] == userMeta.FQDN && rows[0][1] != "ID" {
+			t.Errorf("expected first TestUser row to be ID, got %s", rows[0][1])
+		}
+	})
+
+	t.Run("omitted fields have no JSON name", func(t *testing.T) {
+		SetUnexportedFields(true)
+		defer SetUnexportedFields(false)
+
+		type CatalogSecret struct {
+			Name   string `json:"name"`
+			secret string //nolint:unused // Unexported fields are skipped by encoding/json
+		}
+		instance.cache.Clear()
+		Inspect[CatalogSecret]()
+
+		var buf bytes.Buffer
+		if err := ExportFieldCatalogCSV(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("failed to read CSV: %v", err)
+		}
+		if len(records) != 3 || records[1][2] != "name" || records[2][1] != "secret" || records[2][2] != "" {
+			t.Errorf("expected an empty JSONName for the unexported field, got %v", records)
+		}
+	})
+}
+
+func TestExportGraphML(t *testing.T) {
+	parse := func(t *testing.T, data []byte) graphML {
+		t.Helper()
+		var doc graphML
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("expected well-formed XML: %v", err)
+		}
+		return doc
+	}
+
+	t.Run("user graph", func(t *testing.T) {
+		instance.cache.Clear()
+		userMeta := Scan[User]()
+
+		data, err := ExportGraphML()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc := parse(t, data)
+
+		if doc.XMLName.Space != graphMLNamespace {
+			t.Errorf("expected namespace %q, got %q", graphMLNamespace, doc.XMLName.Space)
+		}
+		if len(doc.Keys) != 4 {
+			t.Errorf("expected 4 key definitions, got %d", len(doc.Keys))
+		}
+		// User, Profile, Address, Order, OrderItem, Settings, Data
+		if len(doc.Graph.Nodes) != 7 {
+			t.Errorf("expected 7 nodes, got %d", len(doc.Graph.Nodes))
+		}
+		// User->Profile, User->Order, User->Settings, Profile->Address, Order->OrderItem, Settings->Data
+		if len(doc.Graph.Edges) != 6 {
+			t.Errorf("expected 6 edges, got %d", len(doc.Graph.Edges))
+		}
+
+		found := false
+		for _, edge := range doc.Graph.Edges {
+			if edge.Source == userMeta.FQDN && edge.Data[0].Value == "Profile" {
+				found = true
+				if edge.Data[1].Value != RelationshipReference {
+					t.Errorf("expected kind %q, got %q", RelationshipReference, edge.Data[1].Value)
+				}
+			}
+		}
+		if !found {
+			t.Error("expected edge for User.Profile")
+		}
+	})
+
+	t.Run("uncached targets become nodes", func(t *testing.T) {
+		instance.cache.Clear()
+		Inspect[User]()
+
+		data, err := ExportGraphML()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc := parse(t, data)
+
+		nodes := make(map[string]graphMLNode)
+		for _, node := range doc.Graph.Nodes {
+			nodes[node.ID] = node
+		}
+		for _, edge := range doc.Graph.Edges {
+			if _, ok := nodes[edge.Target]; !ok {
+				t.Errorf("edge %s targets missing node %s", edge.ID, edge.Target)
+			}
+		}
+
+		profile := nodes[getFQDN(reflect.TypeOf(Profile{}))]
+		if len(profile.Data) != 2 || profile.Data[0].Value != "Profile" {
+			t.Errorf("expected Profile node with typeName attribute, got %+v", profile)
+		}
+	})
+}
+
+// buildSchemaDocument assembles a schema document in memory. It is the
+// reference the streamed export is checked and benchmarked against.
+func buildSchemaDocument(generatedAt time.Time) SchemaDocument {
+	types := instance.cache.All()
+	for fqdn, metadata := range types {
+		types[fqdn] = withoutLayout(metadata)
+	}
+
+	doc := SchemaDocument{
+		Version:     SchemaDocumentVersion,
+		Module:      instance.modulePath,
+		GeneratedAt: generatedAt,
+		Types:       types,
+		Graph: SchemaGraph{
+			Outbound: make(map[string][]string, len(types)),
+			Inbound:  make(map[string][]string, len(types)),
+		},
+	}
+
+	for fqdn := range types {
+		doc.Graph.Outbound[fqdn] = []string{}
+		doc.Graph.Inbound[fqdn] = []string{}
+	}
+
+	for fqdn, metadata := range types {
+		for _, rel := range metadata.Relationships {
+			doc.Graph.Outbound[fqdn] = append(doc.Graph.Outbound[fqdn], rel.To)
+			doc.Graph.Inbound[rel.To] = append(doc.Graph.Inbound[rel.To], fqdn)
+		}
+	}
+
+	for fqdn, targets := range doc.Graph.Outbound {
+		doc.Graph.Outbound[fqdn] = sortedUnique(targets)
+	}
+	for fqdn, sources := range doc.Graph.Inbound {
+		doc.Graph.Inbound[fqdn] = sortedUnique(sources)
+	}
+
+	return doc
+}
+
+// encodeSchemaDocument encodes a schema document as indented JSON.
+// Map keys are sorted by encoding/json, which keeps the output deterministic.
+func encodeSchemaDocument(w io.Writer, doc SchemaDocument) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("sentinel: export schema document: %w", err)
+	}
+	return nil
+}
+
+// populateSyntheticCache fills a fresh cache with n related types.
+func populateSyntheticCache(n int) *Cache {
+	cache := NewCache()
+	for i := 0; i < n; i++ {
+		fqdn := fmt.Sprintf("example.com/app.Type%d", i)
+		next := fmt.Sprintf("example.com/app.Type%d", (i+1)%n)
+		cache.Set(fqdn, Metadata{
+			FQDN:        fqdn,
+			TypeName:    fmt.Sprintf("Type%d", i),
+			PackageName: "example.com/app",
+			Fields: []FieldMetadata{
+				{Name: "ID", Type: "string", Kind: KindScalar, Index: []int{0}, Tags: map[string]string{"json": "id"}},
+				{Name: "Next", Type: "*app.Type", Kind: KindPointer, Index: []int{1}, Tags: map[string]string{"json": "next"}},
+			},
+			Relationships: []TypeRelationship{
+				{From: fqdn, To: next, Field: "Next", Kind: RelationshipReference, ToPackage: "example.com/app", ViaPointer: true},
+			},
+		})
+	}
+	return cache
+}
+
+func BenchmarkExportSchemaDocument(b *testing.B) {
+	original := instance.cache
+	instance.cache = populateSyntheticCache(5000)
+	defer func() { instance.cache = original }()
+
+	generatedAt := time.Now()
+
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := streamSchemaDocument(io.Discard, generatedAt, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("in-memory", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := encodeSchemaDocument(io.Discard, buildSchemaDocument(generatedAt)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
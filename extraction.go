@@ -1,18 +1,22 @@
 package sentinel
 
 import (
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 )
 
 // extractMetadata performs the complete metadata extraction for a type.
 // This is used by Inspect() for single-type inspection (no recursive scanning).
 func (s *Sentinel) extractMetadata(t reflect.Type) Metadata {
-	return s.extractMetadataInternal(t, nil)
+	return s.extractMetadataInternal(t, nil, 0)
 }
 
 // extractMetadataInternal performs metadata extraction with optional recursive scanning.
 // If visited is non-nil, it will recursively scan related types in the same module.
-func (s *Sentinel) extractMetadataInternal(t reflect.Type, visited map[string]bool) Metadata {
+// depth is the number of relationships followed from the root type.
+func (s *Sentinel) extractMetadataInternal(t reflect.Type, visited *visitedSet, depth int) Metadata {
 	if t == nil {
 		return Metadata{}
 	}
@@ -27,10 +31,9 @@ func (s *Sentinel) extractMetadataInternal(t reflect.Type, visited map[string]bo
 	}
 
 	fqdn := getFQDN(t)
-	typeName := getTypeName(t)
 
-	// Check if already visited (cycle detection)
-	if visited != nil && visited[fqdn] {
+	// Mark as visited before processing (cycle detection)
+	if visited != nil && !visited.Visit(fqdn) {
 		// Already visited, return cached metadata
 		if cached, exists := s.cache.Get(fqdn); exists {
 			return cached
@@ -38,50 +41,97 @@ func (s *Sentinel) extractMetadataInternal(t reflect.Type, visited map[string]bo
 		return Metadata{}
 	}
 
-	// Mark as visited before processing
-	if visited != nil {
-		visited[fqdn] = true
-	}
-
 	// Check cache first (if cache exists)
 	if s.cache != nil {
 		if cached, exists := s.cache.Get(fqdn); exists {
 			// Even if cached, we still need to scan relationships if in Scan mode
 			if visited != nil {
 				// Re-extract relationships to trigger recursive scanning
-				_ = s.extractRelationships(t, visited)
+				_ = s.extractRelationships(t, visited, depth)
 			}
 			return cached
 		}
 	}
 
+	metadata := s.buildMetadata(t, visited, depth)
+
+	// Store in cache (if cache exists)
+	if s.cache != nil {
+		s.storeMetadata(fqdn, metadata)
+		if visited != nil {
+			visited.Cached(fqdn)
+		}
+	}
+
+	return metadata
+}
+
+// buildMetadata extracts metadata for a struct type without consulting the cache.
+// If visited is non-nil, related types in the same module are scanned recursively.
+func (s *Sentinel) buildMetadata(t reflect.Type, visited *visitedSet, depth int) Metadata {
+	fqdn := getFQDN(t)
+	typeName := getTypeName(t)
+
 	// Initialize metadata with basic reflection
 	metadata := Metadata{
 		ReflectType: t,
 		FQDN:        fqdn,
 		TypeName:    typeName,
 		PackageName: t.PkgPath(),
+		Size:        t.Size(),
+		Align:       uintptr(t.Align()),
+	}
+
+	// Record the instantiation of generic types
+	if base, args := parseGenericName(t.Name()); len(args) > 0 {
+		metadata.GenericBase = base
+		metadata.TypeArguments = args
 	}
 
 	// Extract fields
 	metadata.Fields = s.extractFieldMetadata(t)
 
+	// Record truncation when the field limit was reached
+	if limit := s.fieldLimit(); limit > 0 && len(metadata.Fields) == limit {
+		if total := len(s.structFields(t)); total > limit {
+			metadata.Truncated = true
+			metadata.TotalFieldCount = total
+		}
+	}
+
+	// Read doc comments from source when enabled
+	s.applySourceDocs(t, &metadata)
+
+	// Append registered virtual fields
+	metadata.Fields = append(metadata.Fields, s.virtualFieldsFor(fqdn)...)
+
+	// Group fields by their group tag
+	metadata.FieldGroups = groupFields(metadata.Fields)
+
+	// Lift tags from blank marker fields
+	metadata.TypeTags = extractTypeTags(t)
+
+	// Detect registered marker interfaces
+	metadata.Markers = s.extractMarkers(t)
+
 	// Extract relationships (will recursively scan if visited is non-nil)
-	metadata.Relationships = s.extractRelationships(t, visited)
+	metadata.Relationships = s.extractRelationships(t, visited, depth)
 
-	// Store in cache (if cache exists)
-	if s.cache != nil {
-		s.cache.Set(fqdn, metadata)
+	// Detect join tables when enabled
+	if s.joinDetectionEnabled() {
+		metadata.JoinTable = isJoinTable(metadata)
 	}
 
 	return metadata
 }
 
 // scanWithVisited recursively inspects a type and all related types within the same module.
-// The visited map prevents infinite loops from circular references.
-func (s *Sentinel) scanWithVisited(t reflect.Type, visited map[string]bool) {
+// The visited set prevents infinite loops from circular references.
+// Warnings raised during the scan are attached to the root type's metadata.
+func (s *Sentinel) scanWithVisited(t reflect.Type, visited *visitedSet) {
 	// All the work is now done by extractMetadataInternal
-	s.extractMetadataInternal(t, visited)
+	s.extractMetadataInternal(t, visited, 0)
+	s.attachWarnings(getFQDN(t), visited)
 }
 
 // extractFieldMetadata extracts field information with registered tags.
@@ -96,11 +146,13 @@ func (s *Sentinel) extractFieldMetadata(t reflect.Type) []FieldMetadata {
 		return fields
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+	limit := s.fieldLimit()
+	codecs := s.codecs()
 
-		if !field.IsExported() {
-			continue
+	for _, field := range s.structFields(t) {
+		// Stop once the field limit is reached
+		if limit > 0 && len(fields) == limit {
+			break
 		}
 
 		// Extract all tags
@@ -113,23 +165,78 @@ func (s *Sentinel) extractFieldMetadata(t reflect.Type) []FieldMetadata {
 				tags[tagName] = tagValue
 			}
 		}
+		aliases := s.tagAliases
 		s.tagMutex.RUnlock()
 
 		// Always include common tags
-		commonTags := []string{"json", "validate", "db", "scope", "encrypt", "redact", "desc", "example"}
+		commonTags := []string{"json", "validate", "db", "scope", "encrypt", "redact", "desc", "example", "group", "erd"}
 		for _, tagName := range commonTags {
 			if tagValue := field.Tag.Get(tagName); tagValue != "" {
 				tags[tagName] = tagValue
 			}
 		}
 
+		// Aliases fill in their canonical tag when the field lacks it; the
+		// first alias in sorted order wins when several share a canonical name
+		for _, alias := range slices.Sorted(maps.Keys(aliases)) {
+			canonical := aliases[alias]
+			if _, ok := tags[canonical]; ok {
+				continue
+			}
+			tagValue := field.Tag.Get(canonical)
+			if tagValue == "" {
+				tagValue = field.Tag.Get(alias)
+			}
+			if tagValue != "" {
+				tags[canonical] = tagValue
+			}
+		}
+
 		fieldMeta := FieldMetadata{
 			Index:       field.Index,
+			Offset:      field.Offset,
 			Name:        field.Name,
 			Type:        field.Type.String(),
 			Kind:        getFieldKind(field.Type),
 			ReflectType: field.Type,
 			Tags:        tags,
+			Anonymous:   field.Anonymous,
+			Exported:    field.IsExported(),
+			Nullable:    isNilable(field.Type),
+		}
+
+		if field.Type.Kind() == reflect.Array {
+			fieldMeta.ArrayLen = field.Type.Len()
+		}
+		fieldMeta.InterfaceMethods = interfaceMethods(field.Type)
+
+		// Nullable wrappers such as sql.NullString are scalars, not structs
+		if underlying, ok := s.nullableUnderlying(field.Type); ok {
+			fieldMeta.Kind = KindScalar
+			fieldMeta.Nullable = true
+			fieldMeta.Underlying = underlying
+		}
+
+		// Registered scalars such as [16]byte UUIDs are scalars, not slices or structs
+		if format, ok := s.scalarFormat(field.Type); ok {
+			fieldMeta.Kind = KindScalar
+			fieldMeta.Format = format
+		}
+		fieldMeta.EnumValues = s.enumStrings(field.Type)
+
+		// Resolve the key encoding/json uses for the field; it skips unexported fields
+		jsonName, included := codecFieldName(fieldMeta, "json")
+		if !fieldMeta.Exported {
+			jsonName, included = "", false
+		}
+		fieldMeta.JSONName = jsonName
+		fieldMeta.JSONOmitted = !included
+		fieldMeta.SerializedNames = serializedNames(fieldMeta, codecs)
+
+		// The deprecated tag marks the field even when its value is empty
+		if note, ok := field.Tag.Lookup("deprecated"); ok {
+			fieldMeta.Deprecated = true
+			fieldMeta.DeprecationNote = note
 		}
 
 		fields = append(fields, fieldMeta)
@@ -137,3 +244,141 @@ func (s *Sentinel) extractFieldMetadata(t reflect.Type) []FieldMetadata {
 
 	return fields
 }
+
+// groupFields maps each group tag value to the names of its fields in
+// declaration order. Fields without a group tag are listed under "".
+func groupFields(fields []FieldMetadata) map[string][]string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	groups := make(map[string][]string)
+	for _, field := range fields {
+		group := field.Tags["group"]
+		groups[group] = append(groups[group], field.Name)
+	}
+	return groups
+}
+
+// extractTypeTags collects every tag declared on blank identifier fields, which
+// are used to annotate the type itself:
+//
+//	type Invoice struct {
+//		_ struct{} `domain:"billing" owner:"team-payments"`
+//	}
+//
+// Blank fields are never exported, so they are already excluded from Fields.
+// When several blank fields declare the same key, the first one wins.
+func extractTypeTags(t reflect.Type) map[string]string {
+	var typeTags map[string]string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+
+		for key, value := range parseStructTag(field.Tag) {
+			if typeTags == nil {
+				typeTags = make(map[string]string)
+			}
+			if _, exists := typeTags[key]; !exists {
+				typeTags[key] = value
+			}
+		}
+	}
+
+	return typeTags
+}
+
+// parseStructTag returns every key:"value" pair in a struct tag, following the
+// conventional format understood by reflect.StructTag.Get. Parsing stops at the
+// first malformed pair.
+func parseStructTag(tag reflect.StructTag) map[string]string {
+	pairs := make(map[string]string)
+
+	for tag != "" {
+		// Skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon; a space, quote or control character is a syntax error
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quoted := string(tag[:i+1])
+		tag = tag[i+1:]
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+		if _, exists := pairs[name]; !exists {
+			pairs[name] = value
+		}
+	}
+
+	return pairs
+}
+
+// structFields returns the fields of a struct type that are listed in Fields:
+// the exported declared fields, plus promoted fields with SetFlattenEmbedded and
+// unexported fields with SetUnexportedFields. Blank fields are never listed.
+func (s *Sentinel) structFields(t reflect.Type) []reflect.StructField {
+	s.configMutex.RLock()
+	flatten, unexported := s.flattenEmbedded, s.unexportedFields
+	s.configMutex.RUnlock()
+
+	listed := func(field reflect.StructField) bool {
+		return field.Name != "_" && (unexported || field.IsExported())
+	}
+
+	var fields []reflect.StructField
+	if flatten {
+		for _, field := range reflect.VisibleFields(t) {
+			if listed(field) {
+				fields = append(fields, field)
+			}
+		}
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); listed(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// fieldLimit returns the configured maximum number of fields per type (0 = unlimited).
+func (s *Sentinel) fieldLimit() int {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.maxFields
+}
@@ -1,6 +1,9 @@
 package sentinel
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"reflect"
 	"strings"
 	"testing"
@@ -182,7 +185,7 @@ func TestExtractMetadata(t *testing.T) {
 }
 
 func TestExtractMetadataInternal(t *testing.T) {
-	t.Run("cache hit with visited map", func(t *testing.T) {
+	t.Run("cache hit with visited set", func(t *testing.T) {
 		instance.cache.Clear()
 
 		type CachedType struct {
@@ -196,17 +199,17 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 
 		typ := reflect.TypeOf(CachedType{})
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
 		// First extraction
-		metadata1 := s.extractMetadataInternal(typ, visited)
+		metadata1 := s.extractMetadataInternal(typ, visited, 0)
 		if metadata1.TypeName != "CachedType" {
 			t.Errorf("expected TypeName 'CachedType', got %s", metadata1.TypeName)
 		}
 
-		// Second call with visited map - should hit cache
-		visited2 := make(map[string]bool)
-		metadata2 := s.extractMetadataInternal(typ, visited2)
+		// Second call with visited set - should hit cache
+		visited2 := newVisitedSet()
+		metadata2 := s.extractMetadataInternal(typ, visited2, 0)
 		if metadata2.TypeName != "CachedType" {
 			t.Errorf("expected cached TypeName 'CachedType', got %s", metadata2.TypeName)
 		}
@@ -224,7 +227,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 
 		typ := reflect.TypeOf(NoCacheType{})
-		metadata := s.extractMetadataInternal(typ, nil)
+		metadata := s.extractMetadataInternal(typ, nil, 0)
 
 		if metadata.TypeName != "NoCacheType" {
 			t.Errorf("expected TypeName 'NoCacheType', got %s", metadata.TypeName)
@@ -234,7 +237,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 	})
 
-	t.Run("cycle detection with visited map", func(t *testing.T) {
+	t.Run("cycle detection with visited set", func(t *testing.T) {
 		instance.cache.Clear()
 
 		type CircularA struct {
@@ -248,18 +251,18 @@ func TestExtractMetadataInternal(t *testing.T) {
 
 		typ := reflect.TypeOf(CircularA{})
 		fqdn := getFQDN(typ)
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
 		// Mark as already visited using FQDN
-		visited[fqdn] = true
+		visited.Visit(fqdn)
 
 		// Should return cached or empty metadata
-		_ = s.extractMetadataInternal(typ, visited)
+		_ = s.extractMetadataInternal(typ, visited, 0)
 
 		// The type should be skipped due to already being visited
 		// If cache exists, it returns cached, otherwise empty
-		if visited[fqdn] != true {
-			t.Error("expected type to remain in visited map")
+		if !visited.Has(fqdn) {
+			t.Error("expected type to remain in visited set")
 		}
 	})
 
@@ -277,13 +280,13 @@ func TestExtractMetadataInternal(t *testing.T) {
 
 		typ := reflect.TypeOf(UncachedType{})
 		fqdn := getFQDN(typ)
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
 		// Mark as visited but don't cache it (using FQDN)
-		visited[fqdn] = true
+		visited.Visit(fqdn)
 
 		// Should return empty metadata since it's visited but not in cache
-		metadata := s.extractMetadataInternal(typ, visited)
+		metadata := s.extractMetadataInternal(typ, visited, 0)
 
 		if metadata.TypeName != "" {
 			t.Errorf("expected empty metadata for visited but uncached type, got %s", metadata.TypeName)
@@ -317,11 +320,11 @@ func TestExtractMetadataInternal(t *testing.T) {
 		instance.cache.Set(fqdn, cachedMeta)
 
 		// Mark as visited AND cached - simulates hitting same type twice in circular ref
-		visited := make(map[string]bool)
-		visited[fqdn] = true
+		visited := newVisitedSet()
+		visited.Visit(fqdn)
 
 		// Should return cached metadata
-		metadata := s.extractMetadataInternal(typ, visited)
+		metadata := s.extractMetadataInternal(typ, visited, 0)
 
 		if metadata.TypeName != "CycleType" {
 			t.Errorf("expected cached TypeName 'CycleType', got %s", metadata.TypeName)
@@ -331,7 +334,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 	})
 
-	t.Run("cached with visited map triggers relationship scan", func(t *testing.T) {
+	t.Run("cached with visited set triggers relationship scan", func(t *testing.T) {
 		instance.cache.Clear()
 
 		type Related struct {
@@ -351,17 +354,17 @@ func TestExtractMetadataInternal(t *testing.T) {
 		relatedType := reflect.TypeOf(Related{})
 		relatedFQDN := getFQDN(relatedType)
 
-		// First call - populate cache without visited map (Inspect mode)
-		_ = s.extractMetadataInternal(rootType, nil)
+		// First call - populate cache without visited set (Inspect mode)
+		_ = s.extractMetadataInternal(rootType, nil, 0)
 
 		// Related should NOT be in cache yet
 		if _, exists := instance.cache.Get(relatedFQDN); exists {
 			t.Errorf("Related (%s) should not be cached after Inspect mode", relatedFQDN)
 		}
 
-		// Second call with visited map (Scan mode) - should trigger relationship scan
-		visited := make(map[string]bool)
-		_ = s.extractMetadataInternal(rootType, visited)
+		// Second call with visited set (Scan mode) - should trigger relationship scan
+		visited := newVisitedSet()
+		_ = s.extractMetadataInternal(rootType, visited, 0)
 
 		// Now Related should be in cache
 		if _, exists := instance.cache.Get(relatedFQDN); !exists {
@@ -375,7 +378,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 			registeredTags: instance.registeredTags,
 		}
 
-		metadata := s.extractMetadataInternal(nil, nil)
+		metadata := s.extractMetadataInternal(nil, nil, 0)
 
 		if metadata.TypeName != "" {
 			t.Errorf("expected empty metadata for nil type, got %s", metadata.TypeName)
@@ -393,7 +396,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 
 		ptrType := reflect.TypeOf(&PointerTest{})
-		metadata := s.extractMetadataInternal(ptrType, nil)
+		metadata := s.extractMetadataInternal(ptrType, nil, 0)
 
 		if metadata.TypeName != "PointerTest" {
 			t.Errorf("expected TypeName 'PointerTest', got %s", metadata.TypeName)
@@ -407,7 +410,7 @@ func TestExtractMetadataInternal(t *testing.T) {
 		}
 
 		intType := reflect.TypeOf(42)
-		metadata := s.extractMetadataInternal(intType, nil)
+		metadata := s.extractMetadataInternal(intType, nil, 0)
 
 		if metadata.TypeName != "" {
 			t.Errorf("expected empty metadata for int type, got %s", metadata.TypeName)
@@ -422,7 +425,7 @@ func TestExtractFieldMetadata(t *testing.T) {
 
 	t.Run("common tags", func(t *testing.T) {
 		type TestStruct struct {
-			Field string `json:"field" validate:"required" db:"field_name" scope:"admin" encrypt:"pii" redact:"***" desc:"Test field" example:"test"`
+			Field string `json:"field" validate:"required" db:"field_name" scope:"admin" encrypt:"pii" redact:"***" desc:"Test field" example:"test" erd:"-"`
 		}
 
 		fields := s.extractFieldMetadata(reflect.TypeOf(TestStruct{}))
@@ -440,6 +443,7 @@ func TestExtractFieldMetadata(t *testing.T) {
 			"redact":   "***",
 			"desc":     "Test field",
 			"example":  "test",
+			"erd":      "-",
 		}
 
 		for tag, expected := range expectedTags {
@@ -503,15 +507,15 @@ func TestExtractFieldMetadata(t *testing.T) {
 			Value string
 		}
 		type AllKindsStruct struct {
-			Scalar    string            `json:"scalar"`
-			Pointer   *string           `json:"pointer"`
-			Slice     []string          `json:"slice"`
-			Array     [5]int            `json:"array"`
-			Struct    Related           `json:"struct"`
-			Map       map[string]int    `json:"map"`
-			Interface interface{}       `json:"interface"`
-			PtrStruct *Related          `json:"ptr_struct"`
-			SlicePtr  []*Related        `json:"slice_ptr"`
+			Scalar    string         `json:"scalar"`
+			Pointer   *string        `json:"pointer"`
+			Slice     []string       `json:"slice"`
+			Array     [5]int         `json:"array"`
+			Struct    Related        `json:"struct"`
+			Map       map[string]int `json:"map"`
+			Interface interface{}    `json:"interface"`
+			PtrStruct *Related       `json:"ptr_struct"`
+			SlicePtr  []*Related     `json:"slice_ptr"`
 		}
 
 		fields := s.extractFieldMetadata(reflect.TypeOf(AllKindsStruct{}))
@@ -581,3 +585,520 @@ func TestExtractFieldMetadata(t *testing.T) {
 		}
 	})
 }
+
+func TestArrayLen(t *testing.T) {
+	s := &Sentinel{
+		registeredTags: make(map[string]bool),
+	}
+
+	type ArrayLenStruct struct {
+		Fixed   [5]int    `json:"fixed"`
+		Dynamic []int     `json:"dynamic"`
+		Empty   [0]int    `json:"empty"`
+		Scalar  int       `json:"scalar"`
+		Nested  [3][2]int `json:"nested"`
+	}
+
+	fields := s.extractFieldMetadata(reflect.TypeOf(ArrayLenStruct{}))
+
+	expected := map[string]int{
+		"Fixed":   5,
+		"Dynamic": 0,
+		"Empty":   0,
+		"Scalar":  0,
+		"Nested":  3,
+	}
+	for _, field := range fields {
+		if field.ArrayLen != expected[field.Name] {
+			t.Errorf("expected %s ArrayLen=%d, got %d", field.Name, expected[field.Name], field.ArrayLen)
+		}
+	}
+
+	for _, field := range fields[:2] {
+		if field.Kind != KindSlice {
+			t.Errorf("expected %s to remain KindSlice, got %s", field.Name, field.Kind)
+		}
+	}
+}
+
+func TestMaxFields(t *testing.T) {
+	wideType := func(n int) reflect.Type {
+		fields := make([]reflect.StructField, n)
+		for i := range fields {
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("F%d", i),
+				Type: reflect.TypeOf(""),
+				Tag:  reflect.StructTag(fmt.Sprintf(`json:"f%d"`, i)),
+			}
+		}
+		return reflect.StructOf(fields)
+	}
+
+	t.Run("truncates wide structs", func(t *testing.T) {
+		s := &Sentinel{
+			registeredTags: make(map[string]bool),
+			maxFields:      100,
+		}
+
+		metadata := s.extractMetadata(wideType(2000))
+
+		if len(metadata.Fields) != 100 {
+			t.Fatalf("expected 100 fields, got %d", len(metadata.Fields))
+		}
+		if !metadata.Truncated {
+			t.Error("expected Truncated to be set")
+		}
+		if metadata.TotalFieldCount != 2000 {
+			t.Errorf("expected TotalFieldCount 2000, got %d", metadata.TotalFieldCount)
+		}
+		if last := metadata.Fields[99]; last.Name != "F99" {
+			t.Errorf("expected first 100 fields to be kept, last is %s", last.Name)
+		}
+	})
+
+	t.Run("struct at the limit is not truncated", func(t *testing.T) {
+		s := &Sentinel{
+			registeredTags: make(map[string]bool),
+			maxFields:      10,
+		}
+
+		metadata := s.extractMetadata(wideType(10))
+
+		if len(metadata.Fields) != 10 {
+			t.Fatalf("expected 10 fields, got %d", len(metadata.Fields))
+		}
+		if metadata.Truncated || metadata.TotalFieldCount != 0 {
+			t.Errorf("expected no truncation, got Truncated=%v TotalFieldCount=%d", metadata.Truncated, metadata.TotalFieldCount)
+		}
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		s := &Sentinel{
+			registeredTags: make(map[string]bool),
+		}
+
+		metadata := s.extractMetadata(wideType(2000))
+
+		if len(metadata.Fields) != 2000 {
+			t.Errorf("expected 2000 fields, got %d", len(metadata.Fields))
+		}
+		if metadata.Truncated {
+			t.Error("expected Truncated to be false")
+		}
+	})
+
+	t.Run("relationships use the full field set", func(t *testing.T) {
+		type TruncatedTarget struct {
+			Value string
+		}
+		type TruncatedRoot struct {
+			A      string
+			B      string
+			C      string
+			Target *TruncatedTarget
+		}
+
+		s := &Sentinel{
+			registeredTags: make(map[string]bool),
+			maxFields:      2,
+		}
+
+		metadata := s.extractMetadata(reflect.TypeOf(TruncatedRoot{}))
+
+		if len(metadata.Fields) != 2 {
+			t.Fatalf("expected 2 fields, got %d", len(metadata.Fields))
+		}
+		if len(metadata.Relationships) != 1 || metadata.Relationships[0].Field != "Target" {
+			t.Errorf("expected relationship via Target, got %+v", metadata.Relationships)
+		}
+	})
+}
+
+func TestTypeTags(t *testing.T) {
+	s := &Sentinel{
+		registeredTags: make(map[string]bool),
+		cache:          NewCache(),
+	}
+
+	type Invoice struct {
+		_      struct{} `domain:"billing" owner:"team-payments"`
+		ID     string   `json:"id"`
+		_      struct{} `owner:"ignored" tier:"gold"`
+		Amount int      `json:"amount"`
+	}
+
+	metadata := s.extractMetadata(reflect.TypeOf(Invoice{}))
+
+	t.Run("marker fields excluded", func(t *testing.T) {
+		if len(metadata.Fields) != 2 {
+			t.Fatalf("expected 2 fields, got %d", len(metadata.Fields))
+		}
+		for _, field := range metadata.Fields {
+			if field.Name == "_" {
+				t.Error("expected blank marker field to be excluded")
+			}
+		}
+	})
+
+	t.Run("tags lifted", func(t *testing.T) {
+		expected := map[string]string{"domain": "billing", "owner": "team-payments", "tier": "gold"}
+		if !reflect.DeepEqual(metadata.TypeTags, expected) {
+			t.Errorf("expected TypeTags %v, got %v", expected, metadata.TypeTags)
+		}
+	})
+
+	t.Run("no marker fields", func(t *testing.T) {
+		type Plain struct {
+			ID string `json:"id"`
+		}
+		if tags := s.extractMetadata(reflect.TypeOf(Plain{})).TypeTags; tags != nil {
+			t.Errorf("expected nil TypeTags, got %v", tags)
+		}
+	})
+}
+
+func TestParseStructTag(t *testing.T) {
+	tests := []struct {
+		expected map[string]string
+		name     string
+		tag      reflect.StructTag
+	}{
+		{name: "empty", tag: ``, expected: map[string]string{}},
+		{name: "single", tag: `owner:"team"`, expected: map[string]string{"owner": "team"}},
+		{name: "multiple", tag: `a:"1"  b:"two words"`, expected: map[string]string{"a": "1", "b": "two words"}},
+		{name: "escaped quote", tag: `a:"say \"hi\""`, expected: map[string]string{"a": `say "hi"`}},
+		{name: "first wins", tag: `a:"1" a:"2"`, expected: map[string]string{"a": "1"}},
+		{name: "malformed stops", tag: `a:"1" b:2 c:"3"`, expected: map[string]string{"a": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseStructTag(tt.tag)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFieldGroups(t *testing.T) {
+	s := &Sentinel{
+		registeredTags: make(map[string]bool),
+		cache:          NewCache(),
+	}
+
+	type Customer struct {
+		Email    string `json:"email" group:"Contact"`
+		ID       string `json:"id"`
+		Card     string `json:"card" group:"Billing"`
+		Phone    string `json:"phone" group:"Contact"`
+		Created  string `json:"created"`
+		Currency string `json:"currency" group:"Billing"`
+	}
+
+	metadata := s.extractMetadata(reflect.TypeOf(Customer{}))
+
+	expected := map[string][]string{
+		"Contact": {"Email", "Phone"},
+		"Billing": {"Card", "Currency"},
+		"":        {"ID", "Created"},
+	}
+	if !reflect.DeepEqual(metadata.FieldGroups, expected) {
+		t.Errorf("expected FieldGroups %v, got %v", expected, metadata.FieldGroups)
+	}
+
+	t.Run("group helper", func(t *testing.T) {
+		billing := metadata.Group("Billing")
+		if len(billing) != 2 || billing[0].Name != "Card" || billing[1].Name != "Currency" {
+			t.Errorf("expected Billing fields [Card Currency], got %v", billing)
+		}
+		if billing[0].Tags["group"] != "Billing" {
+			t.Errorf("expected group tag to be extracted, got %v", billing[0].Tags)
+		}
+
+		ungrouped := metadata.Group("")
+		if len(ungrouped) != 2 || ungrouped[0].Name != "ID" {
+			t.Errorf("expected ungrouped fields [ID Created], got %v", ungrouped)
+		}
+
+		if missing := metadata.Group("Security"); missing != nil {
+			t.Errorf("expected no fields for unknown group, got %v", missing)
+		}
+	})
+}
+
+func TestFieldLookup(t *testing.T) {
+	s := &Sentinel{
+		registeredTags: make(map[string]bool),
+		cache:          NewCache(),
+	}
+
+	type Ticket struct {
+		ID       string `json:"id" scope:"public"`
+		Title    string `json:"title" scope:"public"`
+		Assignee string `json:"assignee" scope:"staff"`
+		Notes    string `json:"notes,omitempty"`
+	}
+
+	metadata := s.extractMetadata(reflect.TypeOf(Ticket{}))
+
+	t.Run("by name", func(t *testing.T) {
+		field, ok := metadata.FieldByName("Assignee")
+		if !ok {
+			t.Fatal("expected Assignee to be found")
+		}
+		if field.JSONName != "assignee" || !reflect.DeepEqual(field.Index, []int{2}) {
+			t.Errorf("expected Assignee field, got %+v", field)
+		}
+
+		if field, ok := metadata.FieldByName("Missing"); ok {
+			t.Errorf("expected Missing not to be found, got %+v", field)
+		}
+		if _, ok := metadata.FieldByName("assignee"); ok {
+			t.Error("expected lookup by name to be case-sensitive")
+		}
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		public := metadata.FieldByTag("scope", "public")
+		if len(public) != 2 || public[0].Name != "ID" || public[1].Name != "Title" {
+			t.Errorf("expected public fields [ID Title], got %v", public)
+		}
+
+		if notes := metadata.FieldByTag("json", "notes,omitempty"); len(notes) != 1 || notes[0].Name != "Notes" {
+			t.Errorf("expected raw tag value to match Notes, got %v", notes)
+		}
+		if none := metadata.FieldByTag("scope", "admin"); none != nil {
+			t.Errorf("expected no fields for unused value, got %v", none)
+		}
+		if none := metadata.FieldByTag("scope", ""); none != nil {
+			t.Errorf("expected fields without the tag not to match an empty value, got %v", none)
+		}
+	})
+}
+
+func TestAnonymousField(t *testing.T) {
+	s := &Sentinel{
+		registeredTags: make(map[string]bool),
+	}
+
+	type AnonBase struct {
+		ID string `json:"id"`
+	}
+	type AnonAudit struct {
+		By string `json:"by"`
+	}
+	type AnonHolder struct {
+		AnonBase
+		*AnonAudit
+		Named AnonBase  `json:"named"`
+		Ptr   *AnonBase `json:"ptr"`
+	}
+
+	fields := s.extractFieldMetadata(reflect.TypeOf(AnonHolder{}))
+
+	expected := map[string]bool{
+		"AnonBase":  true,  // struct embedding
+		"AnonAudit": true,  // pointer embedding
+		"Named":     false, // named struct field
+		"Ptr":       false, // named pointer field
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(fields))
+	}
+	for _, field := range fields {
+		if field.Anonymous != expected[field.Name] {
+			t.Errorf("expected %s Anonymous=%v, got %v", field.Name, expected[field.Name], field.Anonymous)
+		}
+	}
+
+	t.Run("omitted from JSON when false", func(t *testing.T) {
+		named, _ := json.Marshal(fields[2])
+		if strings.Contains(string(named), "anonymous") {
+			t.Errorf("expected anonymous to be omitted, got %s", named)
+		}
+		embedded, _ := json.Marshal(fields[0])
+		if !strings.Contains(string(embedded), `"anonymous":true`) {
+			t.Errorf("expected anonymous to be present, got %s", embedded)
+		}
+	})
+}
+
+func TestJSONName(t *testing.T) {
+	type WireNames struct {
+		Tagged    string `json:"tagged_name"`
+		Excluded  string `json:"-"`
+		Dash      string `json:"-,"`
+		OmitEmpty string `json:",omitempty"`
+		Untagged  string
+	}
+
+	s := &Sentinel{registeredTags: make(map[string]bool)}
+	fields := s.extractFieldMetadata(reflect.TypeOf(WireNames{}))
+
+	expected := []struct {
+		name    string
+		omitted bool
+	}{
+		{"tagged_name", false},
+		{"", true},
+		{"-", false},
+		{"OmitEmpty", false},
+		{"Untagged", false},
+	}
+
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(fields))
+	}
+	for i, want := range expected {
+		if fields[i].JSONName != want.name || fields[i].JSONOmitted != want.omitted {
+			t.Errorf("field %s: expected (%q, %v), got (%q, %v)",
+				fields[i].Name, want.name, want.omitted, fields[i].JSONName, fields[i].JSONOmitted)
+		}
+	}
+
+	t.Run("matches encoding/json", func(t *testing.T) {
+		data, err := json.Marshal(WireNames{OmitEmpty: "set"})
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+
+		var keys map[string]any
+		if err := json.Unmarshal(data, &keys); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		for _, field := range fields {
+			if _, present := keys[field.JSONName]; present == field.JSONOmitted {
+				t.Errorf("field %s: JSONName %q disagrees with %s", field.Name, field.JSONName, data)
+			}
+		}
+	})
+}
+
+func TestNullable(t *testing.T) {
+	type Value struct {
+		Ptr *string
+	}
+	type Nilability struct {
+		Scalar       string
+		Int          int
+		Struct       Value
+		Array        [3]*string
+		Pointer      *string
+		PtrStruct    *Value
+		PtrSlice     *[]string
+		Slice        []string
+		Map          map[string]int
+		Interface    any
+		Chan         chan int
+		Func         func()
+		Error        error
+		ByteSlice    []byte
+		SliceOfPtrs  []*Value
+		MapOfStructs map[string]Value
+	}
+
+	expected := map[string]bool{
+		"Scalar":       false,
+		"Int":          false,
+		"Struct":       false,
+		"Array":        false,
+		"Pointer":      true,
+		"PtrStruct":    true,
+		"PtrSlice":     true,
+		"Slice":        true,
+		"Map":          true,
+		"Interface":    true,
+		"Chan":         true,
+		"Func":         true,
+		"Error":        true,
+		"ByteSlice":    true,
+		"SliceOfPtrs":  true,
+		"MapOfStructs": true,
+	}
+
+	s := &Sentinel{registeredTags: make(map[string]bool)}
+	fields := s.extractFieldMetadata(reflect.TypeOf(Nilability{}))
+
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(fields))
+	}
+	for _, field := range fields {
+		if field.Nullable != expected[field.Name] {
+			t.Errorf("field %s (%s): expected Nullable=%v", field.Name, field.Type, expected[field.Name])
+		}
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	type Legacy struct {
+		Name     string
+		Old      string `json:"old" deprecated:"use Name"`
+		Flagged  string `deprecated:""`
+		Replaced string `json:"-" deprecated:"removed in v2"`
+	}
+
+	s := &Sentinel{registeredTags: make(map[string]bool)}
+	fields := s.extractFieldMetadata(reflect.TypeOf(Legacy{}))
+
+	expected := map[string]string{
+		"Old":      "use Name",
+		"Flagged":  "",
+		"Replaced": "removed in v2",
+	}
+	for _, field := range fields {
+		note, deprecated := expected[field.Name]
+		if field.Deprecated != deprecated || field.DeprecationNote != note {
+			t.Errorf("field %s: expected deprecated=%v note=%q, got deprecated=%v note=%q",
+				field.Name, deprecated, note, field.Deprecated, field.DeprecationNote)
+		}
+	}
+
+	// Deprecated fields can be checked against a policy, here that they are
+	// kept out of new JSON payloads
+	var violations []string
+	for _, field := range fields {
+		if field.Deprecated && !field.JSONOmitted {
+			violations = append(violations, field.Name)
+		}
+	}
+	if want := []string{"Old", "Flagged"}; !reflect.DeepEqual(violations, want) {
+		t.Errorf("expected violations %v, got %v", want, violations)
+	}
+}
+
+type methodSetLogger interface {
+	Logf(format string, args ...any)
+	flush() error
+}
+
+func TestInterfaceMethods(t *testing.T) {
+	type Service struct {
+		Err    error
+		Reader io.Reader
+		Stream io.ReadWriter
+		Logger methodSetLogger
+		Any    any
+		Name   string
+	}
+
+	s := &Sentinel{registeredTags: make(map[string]bool)}
+	fields := s.extractFieldMetadata(reflect.TypeOf(Service{}))
+
+	expected := map[string][]MethodSignature{
+		"Err":    {{Name: "Error", Results: []string{"string"}}},
+		"Reader": {{Name: "Read", Params: []string{"[]uint8"}, Results: []string{"int", "error"}}},
+		"Stream": {
+			{Name: "Read", Params: []string{"[]uint8"}, Results: []string{"int", "error"}},
+			{Name: "Write", Params: []string{"[]uint8"}, Results: []string{"int", "error"}},
+		},
+		"Logger": {{Name: "Logf", Params: []string{"string", "...interface {}"}}},
+		"Any":    nil,
+		"Name":   nil,
+	}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.InterfaceMethods, expected[field.Name]) {
+			t.Errorf("field %s: expected %+v, got %+v", field.Name, expected[field.Name], field.InterfaceMethods)
+		}
+	}
+}
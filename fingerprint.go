@@ -0,0 +1,77 @@
+package sentinel
+
+import (
+	"hash/fnv"
+	"io"
+	"reflect"
+)
+
+// ScanIfChanged scans T only if its cached metadata no longer matches the type.
+// A cheap structural fingerprint (exported field names and types) is computed
+// from reflection and compared against the fingerprint of the cached Fields.
+// When they match, the cached metadata is returned with false and no extraction
+// runs. Otherwise T is re-extracted, related types are scanned as with Scan,
+// and the fresh metadata is cached and returned with true.
+//
+// Reflect types are immutable within a process, so entries extracted by this
+// process never change. The fingerprint only needs Fields, which makes it
+// meaningful for entries placed in the cache from elsewhere without a ReflectType.
+// Panics if T is not a struct type.
+func ScanIfChanged[T any]() (Metadata, bool) {
+	var zero T
+	t, err := structType(reflect.TypeOf(zero))
+	if err != nil {
+		panic(err)
+	}
+
+	fqdn := getFQDN(t)
+	if cached, exists := instance.cache.Get(fqdn); exists {
+		declared := declaredFields(cached.Fields)
+		limit := 0
+		if cached.Truncated {
+			limit = len(declared)
+		}
+		if fingerprintType(instance.structFields(t), limit) == fingerprintFields(declared) {
+			return cached, false
+		}
+	}
+
+	visited := newVisitedSet()
+	visited.Visit(fqdn)
+	metadata := instance.buildMetadata(t, visited, 0)
+	metadata.Warnings = visited.Warnings()
+	instance.storeMetadata(fqdn, metadata)
+
+	return metadata, true
+}
+
+// fingerprintType hashes the names and types of a struct's exported fields,
+// considering at most limit fields (0 = all).
+func fingerprintType(fields []reflect.StructField, limit int) uint64 {
+	h := fnv.New64a()
+	for i, field := range fields {
+		if limit > 0 && i == limit {
+			break
+		}
+		writeFingerprintField(h, field.Name, field.Type.String())
+	}
+	return h.Sum64()
+}
+
+// fingerprintFields hashes the names and types of extracted fields.
+// It matches fingerprintType for metadata extracted from the same type.
+func fingerprintFields(fields []FieldMetadata) uint64 {
+	h := fnv.New64a()
+	for _, field := range fields {
+		writeFingerprintField(h, field.Name, field.Type)
+	}
+	return h.Sum64()
+}
+
+// writeFingerprintField writes one field to a fingerprint hash.
+func writeFingerprintField(w io.Writer, name, typeName string) {
+	_, _ = io.WriteString(w, name)
+	_, _ = w.Write([]byte{0})
+	_, _ = io.WriteString(w, typeName)
+	_, _ = w.Write([]byte{0})
+}
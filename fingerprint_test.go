@@ -0,0 +1,76 @@
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanIfChanged(t *testing.T) {
+	t.Run("first scan reports changed", func(t *testing.T) {
+		instance.cache.Clear()
+
+		metadata, changed := ScanIfChanged[User]()
+		if !changed {
+			t.Error("expected uncached type to report changed")
+		}
+		if metadata.TypeName != "User" {
+			t.Errorf("expected User metadata, got %s", metadata.TypeName)
+		}
+		if _, ok := instance.cache.Get(getFQDN(reflect.TypeOf(Profile{}))); !ok {
+			t.Error("expected related types to be scanned")
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		instance.cache.Clear()
+		Scan[User]()
+
+		_, changed := ScanIfChanged[User]()
+		if changed {
+			t.Error("expected cached type to report unchanged")
+		}
+	})
+
+	t.Run("unchanged imported entry", func(t *testing.T) {
+		instance.cache.Clear()
+		imported := stripReflectTypes(Inspect[User]())
+		instance.cache.Set(imported.FQDN, imported)
+
+		metadata, changed := ScanIfChanged[User]()
+		if changed {
+			t.Error("expected matching imported entry to report unchanged")
+		}
+		if metadata.ReflectType != nil {
+			t.Error("expected the imported entry to be returned as is")
+		}
+	})
+
+	t.Run("changed after import", func(t *testing.T) {
+		instance.cache.Clear()
+		stale := stripReflectTypes(Inspect[User]())
+		stale.Fields = stale.Fields[:len(stale.Fields)-1]
+		instance.cache.Set(stale.FQDN, stale)
+
+		metadata, changed := ScanIfChanged[User]()
+		if !changed {
+			t.Error("expected stale imported entry to report changed")
+		}
+		if metadata.ReflectType == nil || len(metadata.Fields) != len(stale.Fields)+1 {
+			t.Error("expected fresh metadata to be extracted")
+		}
+		if cached, _ := instance.cache.Get(stale.FQDN); cached.ReflectType == nil {
+			t.Error("expected fresh metadata to replace the cached entry")
+		}
+	})
+
+	t.Run("type changed", func(t *testing.T) {
+		instance.cache.Clear()
+		stale := Inspect[User]()
+		stale.Fields[0].Type = "int"
+		instance.cache.Set(stale.FQDN, stale)
+
+		if _, changed := ScanIfChanged[User](); !changed {
+			t.Error("expected a field type change to report changed")
+		}
+	})
+}
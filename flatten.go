@@ -0,0 +1,34 @@
+package sentinel
+
+// SetFlattenEmbedded enables or disables promotion of embedded struct fields,
+// which is off by default. When enabled, Fields also lists the exported fields
+// promoted from anonymous embedded structs, recursively, following Go's
+// promotion rules:
+//
+//	type Base struct {
+//		ID string
+//	}
+//
+//	type Extended struct {
+//		Base
+//		Name string
+//	}
+//
+// Extended then has the fields Base, ID and Name, in that order: promoted
+// fields follow their embedding field, as the JSON encoder sees them. A
+// promoted field's Index is its full path (ID has Index [0 0]), so
+// reflect.Value.FieldByIndex reaches it; note that FieldByIndex panics when the
+// path crosses a nil embedded pointer.
+// A field declared closer to the outer struct shadows promoted fields with the
+// same name, and names that are ambiguous at the same depth are left out, as
+// they are for the compiler. Embedded pointers that cycle back to an enclosing
+// type are not followed.
+//
+// Relationships are still extracted from declared fields only. Only affects
+// types extracted after the call.
+func SetFlattenEmbedded(enabled bool) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.flattenEmbedded = enabled
+}
@@ -0,0 +1,235 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type FlatBase struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	secret  string //nolint:unused // Verifies unexported fields are not promoted
+	Created int64  `json:"created"`
+}
+
+type FlatAudit struct {
+	FlatBase
+	Revision int `json:"revision"`
+}
+
+type FlatExtended struct {
+	FlatAudit
+	Name  string `json:"display_name"`
+	Email string `json:"email"`
+}
+
+type FlatLeft struct {
+	Tag string
+}
+
+type FlatRight struct {
+	Tag string
+}
+
+type FlatAmbiguous struct {
+	FlatLeft
+	FlatRight
+}
+
+type FlatOrdered struct {
+	First string
+	FlatAudit
+	Last string
+}
+
+type FlatNode struct {
+	*FlatNode
+	Value string
+}
+
+type flatHidden struct {
+	Visible string
+}
+
+type FlatUnexportedEmbed struct {
+	flatHidden
+	Own string
+}
+
+func flatFieldIndexes(fields []FieldMetadata) map[string][]int {
+	indexes := make(map[string][]int, len(fields))
+	for _, field := range fields {
+		indexes[field.Name] = field.Index
+	}
+	return indexes
+}
+
+func TestFlattenEmbedded(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fields := Inspect[FlatExtended]().Fields
+		if len(fields) != 3 {
+			t.Fatalf("expected only declared fields, got %d", len(fields))
+		}
+	})
+
+	Reset()
+	SetFlattenEmbedded(true)
+
+	t.Run("promotes fields with full index paths", func(t *testing.T) {
+		indexes := flatFieldIndexes(Inspect[FlatExtended]().Fields)
+
+		expected := map[string][]int{
+			"FlatAudit": {0},
+			"FlatBase":  {0, 0},
+			"ID":        {0, 0, 0},
+			"Created":   {0, 0, 3},
+			"Revision":  {0, 1},
+			"Name":      {1},
+			"Email":     {2},
+		}
+		if len(indexes) != len(expected) {
+			t.Errorf("expected %d fields, got %v", len(expected), indexes)
+		}
+		for name, index := range expected {
+			if !reflect.DeepEqual(indexes[name], index) {
+				t.Errorf("field %s: expected index %v, got %v", name, index, indexes[name])
+			}
+		}
+	})
+
+	t.Run("index reaches the promoted value", func(t *testing.T) {
+		value := reflect.ValueOf(FlatExtended{
+			FlatAudit: FlatAudit{FlatBase: FlatBase{ID: "u-1"}},
+		})
+		for _, field := range Inspect[FlatExtended]().Fields {
+			if field.Name == "ID" {
+				if got := value.FieldByIndex(field.Index).String(); got != "u-1" {
+					t.Errorf("expected u-1, got %q", got)
+				}
+			}
+		}
+	})
+
+	t.Run("promoted fields appear at the embedding point", func(t *testing.T) {
+		fields := Inspect[FlatOrdered]().Fields
+
+		names := make([]string, 0, len(fields))
+		for _, field := range fields {
+			names = append(names, field.Name)
+		}
+
+		expected := []string{"First", "FlatAudit", "FlatBase", "ID", "Name", "Created", "Revision", "Last"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("expected order %v, got %v", expected, names)
+		}
+	})
+
+	t.Run("order matches the JSON encoder", func(t *testing.T) {
+		data, err := json.Marshal(FlatOrdered{})
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+
+		var keys []string
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		_, _ = decoder.Token()
+		for decoder.More() {
+			key, _ := decoder.Token()
+			keys = append(keys, key.(string))
+			var skip json.RawMessage
+			_ = decoder.Decode(&skip)
+		}
+
+		var tagged []string
+		for _, field := range Inspect[FlatOrdered]().Fields {
+			if field.Anonymous {
+				continue
+			}
+			tagged = append(tagged, field.JSONName)
+		}
+
+		if !reflect.DeepEqual(tagged, keys) {
+			t.Errorf("expected JSON key order %v, got %v", keys, tagged)
+		}
+	})
+
+	t.Run("outer field shadows promoted field", func(t *testing.T) {
+		for _, field := range Inspect[FlatExtended]().Fields {
+			if field.Name == "Name" && field.Tags["json"] != "display_name" {
+				t.Errorf("expected outer Name to win, got tags %v", field.Tags)
+			}
+		}
+	})
+
+	t.Run("ambiguous fields are omitted", func(t *testing.T) {
+		indexes := flatFieldIndexes(Inspect[FlatAmbiguous]().Fields)
+		if _, exists := indexes["Tag"]; exists {
+			t.Error("expected ambiguous Tag to be omitted")
+		}
+		if len(indexes) != 2 {
+			t.Errorf("expected only the embedded fields, got %v", indexes)
+		}
+	})
+
+	t.Run("self-referencing embedded pointer", func(t *testing.T) {
+		indexes := flatFieldIndexes(Inspect[FlatNode]().Fields)
+		expected := map[string][]int{"FlatNode": {0}, "Value": {1}}
+		if !reflect.DeepEqual(indexes, expected) {
+			t.Errorf("expected %v, got %v", expected, indexes)
+		}
+	})
+
+	t.Run("unexported embedded struct promotes exported fields", func(t *testing.T) {
+		indexes := flatFieldIndexes(Inspect[FlatUnexportedEmbed]().Fields)
+		expected := map[string][]int{"Visible": {0, 0}, "Own": {1}}
+		if !reflect.DeepEqual(indexes, expected) {
+			t.Errorf("expected %v, got %v", expected, indexes)
+		}
+	})
+
+	t.Run("relationships come from declared fields", func(t *testing.T) {
+		rels := Inspect[FlatExtended]().Relationships
+		if len(rels) != 1 || rels[0].Field != "FlatAudit" {
+			t.Errorf("expected only the FlatAudit embedding, got %+v", rels)
+		}
+	})
+
+	t.Run("build reflect type skips promoted fields", func(t *testing.T) {
+		built, err := Inspect[FlatExtended]().BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if built.NumField() != 3 {
+			t.Errorf("expected 3 declared fields, got %d", built.NumField())
+		}
+	})
+
+	t.Run("field limit counts promoted fields", func(t *testing.T) {
+		Reset()
+		SetFlattenEmbedded(true)
+		SetMaxFields(2)
+
+		metadata := Inspect[FlatExtended]()
+		if !metadata.Truncated || metadata.TotalFieldCount != 7 {
+			t.Errorf("expected truncation from 7 fields, got truncated=%v total=%d",
+				metadata.Truncated, metadata.TotalFieldCount)
+		}
+	})
+
+	t.Run("scan if changed matches flattened fields", func(t *testing.T) {
+		Reset()
+		SetFlattenEmbedded(true)
+
+		Inspect[FlatExtended]()
+		if _, changed := ScanIfChanged[FlatExtended](); changed {
+			t.Error("expected flattened metadata to match its fingerprint")
+		}
+	})
+}
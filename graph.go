@@ -0,0 +1,263 @@
+package sentinel
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LoadStep is a single step in a load plan produced by LoadOrder.
+type LoadStep struct {
+	Via   *TypeRelationship `json:"via,omitempty"` // Relationship that first reached this type (nil for the root)
+	FQDN  string            `json:"fqdn"`
+	Depth int               `json:"depth"`
+}
+
+// LoadOrder returns a breadth-first load plan for the type graph reachable from
+// the given root. Each reachable type appears once, at the minimum depth it can
+// be reached, so related collections are ordered level by level away from the
+// root. Only relationships of cached types are followed; Scan the root first to
+// cover the whole graph. Returns ErrNotCached if the root is not cached.
+func LoadOrder(rootFQDN string) ([]LoadStep, error) {
+	if _, exists := instance.cache.Get(rootFQDN); !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, rootFQDN)
+	}
+
+	steps := []LoadStep{{FQDN: rootFQDN}}
+	seen := map[string]bool{rootFQDN: true}
+
+	for i := 0; i < len(steps); i++ {
+		current := steps[i]
+		metadata, exists := instance.cache.Get(current.FQDN)
+		if !exists {
+			continue
+		}
+
+		for j := range metadata.Relationships {
+			rel := metadata.Relationships[j]
+			if seen[rel.To] {
+				continue
+			}
+			seen[rel.To] = true
+			steps = append(steps, LoadStep{
+				FQDN:  rel.To,
+				Via:   &rel,
+				Depth: current.Depth + 1,
+			})
+		}
+	}
+
+	return steps, nil
+}
+
+// ErrCycle is returned by TopologicalSort when the relationship graph has a cycle.
+var ErrCycle = errors.New("sentinel: relationship cycle")
+
+// CycleError reports the cycles that prevent a topological sort. It unwraps to
+// ErrCycle.
+type CycleError struct {
+	Cycles [][]string // Sorted FQDNs of each cycle, sorted by first FQDN
+}
+
+// Error implements the error interface.
+func (e *CycleError) Error() string {
+	cycles := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		cycles[i] = strings.Join(cycle, ", ")
+	}
+	return fmt.Sprintf("%s: %s", ErrCycle, strings.Join(cycles, "; "))
+}
+
+// Unwrap returns ErrCycle so errors.Is(err, ErrCycle) holds.
+func (*CycleError) Unwrap() error {
+	return ErrCycle
+}
+
+// TopologicalSort returns the FQDNs of all cached types ordered so that every
+// type comes after the types it depends on: the targets of its references,
+// collections and maps, and the structs it embeds. Relationships to types
+// that are not cached are ignored. Types that do not depend on each other are
+// ordered by FQDN, so the result is stable.
+//
+// Returns a *CycleError listing every cycle when the types cannot be ordered,
+// including a type that refers to itself, such as a tree node with a Parent
+// pointer. The caller decides which edges to break.
+func TopologicalSort() ([]string, error) {
+	schema := instance.cache.All()
+
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	// Each type depends on the cached targets of its relationships
+	dependencies := make(map[string][]string, len(schema))
+	dependents := make(map[string][]string, len(schema))
+	for _, fqdn := range fqdns {
+		for _, rel := range schema[fqdn].Relationships {
+			if _, cached := schema[rel.To]; cached {
+				dependencies[fqdn] = append(dependencies[fqdn], rel.To)
+			}
+		}
+		dependencies[fqdn] = sortedUnique(dependencies[fqdn])
+		for _, to := range dependencies[fqdn] {
+			dependents[to] = append(dependents[to], fqdn)
+		}
+	}
+
+	if cycles := findCycles(fqdns, dependencies); len(cycles) > 0 {
+		return nil, &CycleError{Cycles: cycles}
+	}
+
+	// Kahn's algorithm, taking the smallest ready FQDN first
+	pending := make(map[string]int, len(fqdns))
+	var ready []string
+	for _, fqdn := range fqdns {
+		pending[fqdn] = len(dependencies[fqdn])
+		if pending[fqdn] == 0 {
+			ready = append(ready, fqdn)
+		}
+	}
+
+	order := make([]string, 0, len(fqdns))
+	for len(ready) > 0 {
+		fqdn := ready[0]
+		ready = ready[1:]
+		order = append(order, fqdn)
+
+		for _, dependent := range dependents[fqdn] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				i := sort.SearchStrings(ready, dependent)
+				ready = slices.Insert(ready, i, dependent)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// DetectIllegalValueCycles reports relationship cycles made entirely of value
+// edges: struct references and embeddings not held through a pointer, and
+// fixed-size arrays of values. Such a type would have infinite size, so the Go
+// compiler rejects it; a value cycle in the cache therefore indicates metadata
+// that was constructed or imported incorrectly. Cycles through pointers, slices
+// or maps are legal and ignored.
+//
+// Each entry lists the sorted FQDNs of one cycle (a strongly connected
+// component of the value graph, or a type that contains itself by value), and
+// entries are sorted by their first FQDN. Returns nil when there are none.
+func DetectIllegalValueCycles() [][]string {
+	schema := instance.cache.All()
+
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	// Build the value-edge graph
+	edges := make(map[string][]string, len(schema))
+	for _, fqdn := range fqdns {
+		metadata := schema[fqdn]
+		for _, rel := range metadata.Relationships {
+			if isValueEdge(metadata, rel) {
+				edges[fqdn] = append(edges[fqdn], rel.To)
+			}
+		}
+		edges[fqdn] = sortedUnique(edges[fqdn])
+	}
+
+	return findCycles(fqdns, edges)
+}
+
+// findCycles returns the cycles of a directed graph: each strongly connected
+// component with more than one node, or a single node with an edge to itself,
+// as sorted FQDNs. Entries are sorted by their first FQDN. Nodes are visited
+// in the order of fqdns, which should be sorted for stable output.
+func findCycles(fqdns []string, edges map[string][]string) [][]string {
+	// Tarjan's strongly connected components
+	var (
+		cycles  [][]string
+		stack   []string
+		counter int
+	)
+	index := make(map[string]int, len(fqdns))
+	lowlink := make(map[string]int, len(fqdns))
+	onStack := make(map[string]bool, len(fqdns))
+
+	var connect func(fqdn string)
+	connect = func(fqdn string) {
+		index[fqdn] = counter
+		lowlink[fqdn] = counter
+		counter++
+		stack = append(stack, fqdn)
+		onStack[fqdn] = true
+
+		selfLoop := false
+		for _, to := range edges[fqdn] {
+			if to == fqdn {
+				selfLoop = true
+			}
+			if _, visited := index[to]; !visited {
+				connect(to)
+				lowlink[fqdn] = min(lowlink[fqdn], lowlink[to])
+			} else if onStack[to] {
+				lowlink[fqdn] = min(lowlink[fqdn], index[to])
+			}
+		}
+
+		if lowlink[fqdn] != index[fqdn] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == fqdn {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, fqdn := range fqdns {
+		if _, visited := index[fqdn]; !visited {
+			connect(fqdn)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// isValueEdge reports whether a relationship embeds its target by value,
+// making the target's size part of the source's size.
+func isValueEdge(from Metadata, rel TypeRelationship) bool {
+	if rel.ViaPointer {
+		return false
+	}
+
+	switch rel.Kind {
+	case RelationshipReference, RelationshipEmbedding:
+		return true
+	case RelationshipCollection:
+		// Arrays hold their elements inline; slices do not
+		for _, field := range from.Fields {
+			if field.Name == rel.Field {
+				return strings.HasPrefix(field.Type, "[") && !strings.HasPrefix(field.Type, "[]")
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,207 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoadOrder(t *testing.T) {
+	instance.cache.Clear()
+	userMeta := Scan[User]()
+
+	steps, err := LoadOrder(userMeta.FQDN)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	depths := make(map[string]int)
+	for _, step := range steps {
+		if _, dup := depths[step.FQDN]; dup {
+			t.Errorf("type %s appears more than once", step.FQDN)
+		}
+		depths[step.FQDN] = step.Depth
+	}
+
+	t.Run("root is first at depth zero", func(t *testing.T) {
+		if steps[0].FQDN != userMeta.FQDN || steps[0].Depth != 0 || steps[0].Via != nil {
+			t.Errorf("unexpected root step: %+v", steps[0])
+		}
+	})
+
+	t.Run("levels", func(t *testing.T) {
+		expected := map[string]int{
+			getFQDN(reflect.TypeOf(Profile{})):   1,
+			getFQDN(reflect.TypeOf(Order{})):     1,
+			getFQDN(reflect.TypeOf(Settings{})):  1,
+			getFQDN(reflect.TypeOf(Address{})):   2,
+			getFQDN(reflect.TypeOf(OrderItem{})): 2,
+			getFQDN(reflect.TypeOf(Data{})):      2,
+		}
+		for fqdn, depth := range expected {
+			actual, ok := depths[fqdn]
+			if !ok {
+				t.Errorf("expected %s in load order", fqdn)
+				continue
+			}
+			if actual != depth {
+				t.Errorf("expected %s at depth %d, got %d", fqdn, depth, actual)
+			}
+		}
+	})
+
+	t.Run("levels are ordered", func(t *testing.T) {
+		for i := 1; i < len(steps); i++ {
+			if steps[i].Depth < steps[i-1].Depth {
+				t.Errorf("step %d (depth %d) follows depth %d", i, steps[i].Depth, steps[i-1].Depth)
+			}
+		}
+	})
+
+	t.Run("records the relationship used", func(t *testing.T) {
+		for _, step := range steps[1:] {
+			if step.Via == nil {
+				t.Errorf("expected via relationship for %s", step.FQDN)
+				continue
+			}
+			if step.Via.To != step.FQDN {
+				t.Errorf("expected via.To %s, got %s", step.FQDN, step.Via.To)
+			}
+		}
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		_, err := LoadOrder("example.com/missing.Type")
+		if !errors.Is(err, ErrNotCached) {
+			t.Errorf("expected ErrNotCached, got %v", err)
+		}
+	})
+}
+
+type CycleParent struct {
+	Children []CycleChild `json:"children"`
+}
+
+type CycleChild struct {
+	Parent *CycleParent `json:"parent"`
+	Pair   [2]CycleLeaf `json:"pair"`
+}
+
+type CycleLeaf struct {
+	Siblings map[string]CycleLeaf `json:"siblings"`
+}
+
+func TestDetectIllegalValueCycles(t *testing.T) {
+	t.Run("pointer, slice and map cycles are legal", func(t *testing.T) {
+		instance.cache.Clear()
+		Scan[CycleParent]()
+
+		if cycles := DetectIllegalValueCycles(); cycles != nil {
+			t.Errorf("expected no illegal cycles, got %v", cycles)
+		}
+	})
+
+	// Value cycles do not compile, so they can only reach the cache through
+	// fabricated or imported metadata.
+	t.Run("fabricated value cycles", func(t *testing.T) {
+		instance.cache.Clear()
+		instance.cache.Set("app.A", Metadata{
+			FQDN:   "app.A",
+			Fields: []FieldMetadata{{Name: "B", Type: "app.B", Kind: KindStruct}},
+			Relationships: []TypeRelationship{
+				{From: "app.A", To: "app.B", Field: "B", Kind: RelationshipReference},
+			},
+		})
+		instance.cache.Set("app.B", Metadata{
+			FQDN:   "app.B",
+			Fields: []FieldMetadata{{Name: "A", Type: "app.A", Kind: KindStruct}},
+			Relationships: []TypeRelationship{
+				{From: "app.B", To: "app.A", Field: "A", Kind: RelationshipEmbedding},
+			},
+		})
+		instance.cache.Set("app.Self", Metadata{
+			FQDN:   "app.Self",
+			Fields: []FieldMetadata{{Name: "Copies", Type: "[3]app.Self", Kind: KindSlice}},
+			Relationships: []TypeRelationship{
+				{From: "app.Self", To: "app.Self", Field: "Copies", Kind: RelationshipCollection},
+			},
+		})
+		instance.cache.Set("app.Safe", Metadata{
+			FQDN:   "app.Safe",
+			Fields: []FieldMetadata{{Name: "Next", Type: "*app.Safe", Kind: KindPointer}},
+			Relationships: []TypeRelationship{
+				{From: "app.Safe", To: "app.Safe", Field: "Next", Kind: RelationshipReference, ViaPointer: true},
+			},
+		})
+
+		expected := [][]string{{"app.A", "app.B"}, {"app.Self"}}
+		if cycles := DetectIllegalValueCycles(); !reflect.DeepEqual(cycles, expected) {
+			t.Errorf("expected cycles %v, got %v", expected, cycles)
+		}
+	})
+}
+
+func TestTopologicalSort(t *testing.T) {
+	// fabricate caches metadata with the given relationships by FQDN
+	fabricate := func(graph map[string][]TypeRelationship) {
+		instance.cache.Clear()
+		for fqdn, rels := range graph {
+			instance.cache.Set(fqdn, Metadata{FQDN: fqdn, Relationships: rels})
+		}
+	}
+
+	t.Run("dependencies come first", func(t *testing.T) {
+		fabricate(map[string][]TypeRelationship{
+			"app.Order":   {{To: "app.Item", Kind: RelationshipCollection}, {To: "app.User", Kind: RelationshipReference}},
+			"app.User":    {{To: "app.Base", Kind: RelationshipEmbedding}, {To: "app.Setting", Kind: RelationshipMap}},
+			"app.Item":    {{To: "app.Product", Kind: RelationshipReference}, {To: "app.External", Kind: RelationshipReference}},
+			"app.Base":    nil,
+			"app.Setting": nil,
+			"app.Product": nil,
+		})
+
+		expected := []string{"app.Base", "app.Product", "app.Item", "app.Setting", "app.User", "app.Order"}
+		order, err := TopologicalSort()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(order, expected) {
+			t.Errorf("expected %v, got %v", expected, order)
+		}
+	})
+
+	t.Run("cycles are reported", func(t *testing.T) {
+		fabricate(map[string][]TypeRelationship{
+			"app.Parent": {{To: "app.Child", Kind: RelationshipCollection}},
+			"app.Child":  {{To: "app.Parent", Kind: RelationshipReference}},
+			"app.Node":   {{To: "app.Node", Kind: RelationshipReference}},
+			"app.Leaf":   nil,
+		})
+
+		order, err := TopologicalSort()
+		if order != nil || !errors.Is(err, ErrCycle) {
+			t.Fatalf("expected ErrCycle, got %v, %v", order, err)
+		}
+		var cycleErr *CycleError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("expected *CycleError, got %T", err)
+		}
+		expected := [][]string{{"app.Child", "app.Parent"}, {"app.Node"}}
+		if !reflect.DeepEqual(cycleErr.Cycles, expected) {
+			t.Errorf("expected cycles %v, got %v", expected, cycleErr.Cycles)
+		}
+	})
+
+	t.Run("scanned types", func(t *testing.T) {
+		instance.cache.Clear()
+		Scan[Profile]()
+
+		expected := []string{getFQDN(reflect.TypeOf(Address{})), getFQDN(reflect.TypeOf(Profile{}))}
+		order, err := TopologicalSort()
+		if err != nil || !reflect.DeepEqual(order, expected) {
+			t.Errorf("expected %v, got %v, %v", expected, order, err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package sentinel
+
+import "sort"
+
+// SetJoinDetection enables or disables join-table detection, which is off by
+// default. When enabled, a type whose only relationships are exactly two
+// references to distinct types, and whose other fields are all scalars (such
+// as IDs and timestamps), is marked Metadata.JoinTable:
+//
+//	type UserRole struct {
+//		User      *User
+//		Role      *Role
+//		GrantedAt int64
+//	}
+//
+// Only affects types extracted after the call.
+func SetJoinDetection(enabled bool) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.joinDetection = enabled
+}
+
+// DerivedRelationships returns the many-to-many relationships implied by the
+// cached join tables, one per join table, sorted by join table FQDN. From and
+// To are the two endpoints in field order, Field holds the join table's FQDN,
+// and Kind is RelationshipManyToMany.
+func DerivedRelationships() []TypeRelationship {
+	var derived []TypeRelationship
+	instance.cache.ForEach(func(fqdn string, metadata Metadata) bool {
+		if !metadata.JoinTable || len(metadata.Relationships) != 2 {
+			return true
+		}
+		left, right := metadata.Relationships[0], metadata.Relationships[1]
+		derived = append(derived, TypeRelationship{
+			From:        left.To,
+			To:          right.To,
+			Field:       fqdn,
+			Kind:        RelationshipManyToMany,
+			Cardinality: CardinalityMany,
+			ToPackage:   right.ToPackage,
+		})
+		return true
+	})
+
+	sort.Slice(derived, func(i, j int) bool { return derived[i].Field < derived[j].Field })
+	return derived
+}
+
+// joinDetectionEnabled reports whether join-table detection is enabled.
+func (s *Sentinel) joinDetectionEnabled() bool {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.joinDetection
+}
+
+// isJoinTable applies the join-table heuristic to extracted metadata.
+func isJoinTable(metadata Metadata) bool {
+	if len(metadata.Relationships) != 2 {
+		return false
+	}
+
+	linkFields := make(map[string]bool, 2)
+	for _, rel := range metadata.Relationships {
+		if rel.Kind != RelationshipReference {
+			return false
+		}
+		linkFields[rel.Field] = true
+	}
+	if metadata.Relationships[0].To == metadata.Relationships[1].To {
+		return false
+	}
+
+	for _, field := range declaredFields(metadata.Fields) {
+		if !linkFields[field.Name] && field.Kind != KindScalar {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"testing"
+)
+
+type JoinUser struct {
+	ID string `json:"id"`
+}
+
+type JoinRole struct {
+	Name string `json:"name"`
+}
+
+type UserRole struct {
+	User      *JoinUser `json:"user"`
+	Role      *JoinRole `json:"role"`
+	UserID    string    `json:"user_id"`
+	GrantedAt int64     `json:"granted_at"`
+}
+
+type RoleAssignment struct {
+	User    *JoinUser `json:"user"`
+	Role    *JoinRole `json:"role"`
+	Grantor *JoinUser `json:"grantor"`
+}
+
+type TaggedRole struct {
+	User   *JoinUser `json:"user"`
+	Role   *JoinRole `json:"role"`
+	Labels []string  `json:"labels"`
+}
+
+func TestJoinDetection(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		if Inspect[UserRole]().JoinTable {
+			t.Error("expected join detection to be opt-in")
+		}
+	})
+
+	Reset()
+	SetJoinDetection(true)
+
+	t.Run("classic join struct", func(t *testing.T) {
+		if !Inspect[UserRole]().JoinTable {
+			t.Error("expected UserRole to be marked as a join table")
+		}
+	})
+
+	t.Run("extra reference fields", func(t *testing.T) {
+		if Inspect[RoleAssignment]().JoinTable {
+			t.Error("expected three references not to be a join table")
+		}
+	})
+
+	t.Run("non-scalar payload", func(t *testing.T) {
+		if Inspect[TaggedRole]().JoinTable {
+			t.Error("expected a non-scalar field to disqualify the join table")
+		}
+	})
+
+	t.Run("derived relationships", func(t *testing.T) {
+		joinMeta := Inspect[UserRole]()
+		derived := DerivedRelationships()
+
+		if len(derived) != 1 {
+			t.Fatalf("expected 1 derived relationship, got %+v", derived)
+		}
+		rel := derived[0]
+		if rel.Kind != RelationshipManyToMany || rel.Field != joinMeta.FQDN {
+			t.Errorf("unexpected derived relationship: %+v", rel)
+		}
+		if rel.From != Inspect[JoinUser]().FQDN || rel.To != Inspect[JoinRole]().FQDN {
+			t.Errorf("expected JoinUser -> JoinRole, got %s -> %s", rel.From, rel.To)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package sentinel
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GenerateJSONPaths maps the Go field path of every field reachable from T,
+// such as "Profile.Address.City", to its JSONPath, such as
+// "$.profile.address.city". Paths are composed from JSON names along the
+// relationship chain: collections add a [*] segment and maps a .* segment,
+// and the fields of embedded structs without a json name are promoted into
+// the parent object, as encoding/json writes them. Fields excluded with
+// `json:"-"` are skipped, and JSON names that are not plain identifiers use
+// bracket notation ($['first name']).
+//
+// Only cached related types are followed, so Scan T first; a relationship to
+// a type that is already on the current path is not followed again.
+// Panics if T is not a struct type.
+func GenerateJSONPaths[T any]() map[string]string {
+	paths := make(map[string]string)
+	walkFieldPaths(Inspect[T](), func(field FieldMetadata, rel *TypeRelationship, goPath, jsonPath string) bool {
+		if field.JSONOmitted {
+			return false
+		}
+		if rel == nil || rel.Kind != RelationshipEmbedding || !rel.EmbeddedInline {
+			paths[goPath] = jsonPath
+		}
+		return true
+	})
+	return paths
+}
+
+// SensitiveFieldPaths returns the sorted Go field paths, in the form used by
+// GenerateJSONPaths, of every field reachable from T that carries one of the
+// given tags, encrypt and redact by default. Fields excluded from JSON are
+// included, as they often hold secrets. Tags other than the built-in ones
+// must be registered with Tag before extraction.
+//
+// Only cached related types are followed, so Scan T first; a relationship to
+// a type that is already on the current path is not followed again.
+// Panics if T is not a struct type.
+func SensitiveFieldPaths[T any](sensitiveTags ...string) []string {
+	if len(sensitiveTags) == 0 {
+		sensitiveTags = []string{"encrypt", "redact"}
+	}
+
+	var paths []string
+	walkFieldPaths(Inspect[T](), func(field FieldMetadata, _ *TypeRelationship, goPath, _ string) bool {
+		for _, tag := range sensitiveTags {
+			if _, ok := field.Tags[tag]; ok {
+				paths = append(paths, goPath)
+				break
+			}
+		}
+		return true
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// fieldPathVisitor is called for each field reached by walkFieldPaths with the
+// relationship the field creates, if any. Returning false stops the walk from
+// following that relationship.
+type fieldPathVisitor func(field FieldMetadata, rel *TypeRelationship, goPath, jsonPath string) bool
+
+// walkFieldPaths visits every field reachable from a type through cached
+// relationships, depth first in field order.
+func walkFieldPaths(root Metadata, visit fieldPathVisitor) {
+	walkFieldPathsFrom(root, "", "$", map[string]bool{root.FQDN: true}, visit)
+}
+
+// walkFieldPathsFrom visits a type's fields and recurses into their cached
+// relationship targets that are not already on the current path.
+func walkFieldPathsFrom(metadata Metadata, goPrefix, jsonPrefix string, onPath map[string]bool, visit fieldPathVisitor) {
+	for _, field := range metadata.Fields {
+		// Promoted fields are reached through their embedding field
+		if len(field.Index) > 1 {
+			continue
+		}
+
+		goPath := field.Name
+		if goPrefix != "" {
+			goPath = goPrefix + "." + field.Name
+		}
+
+		rel := fieldRelationship(metadata, field.Name)
+		jsonPath := jsonPrefix
+		if rel == nil || rel.Kind != RelationshipEmbedding || !rel.EmbeddedInline {
+			jsonPath += jsonPathSegment(field.JSONName)
+		}
+
+		if !visit(field, rel, goPath, jsonPath) || rel == nil || onPath[rel.To] {
+			continue
+		}
+		target, exists := instance.cache.Get(rel.To)
+		if !exists {
+			continue
+		}
+
+		switch rel.Kind {
+		case RelationshipCollection:
+			jsonPath += "[*]"
+		case RelationshipMap:
+			jsonPath += ".*"
+		}
+
+		onPath[rel.To] = true
+		walkFieldPathsFrom(target, goPath, jsonPath, onPath, visit)
+		delete(onPath, rel.To)
+	}
+}
+
+// jsonPathSegment returns the JSONPath segment selecting a member by name.
+func jsonPathSegment(name string) string {
+	if isJSONPathIdentifier(name) {
+		return "." + name
+	}
+	return "['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(name) + "']"
+}
+
+// isJSONPathIdentifier reports whether name can be used in dot notation.
+func isJSONPathIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
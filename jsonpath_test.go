@@ -0,0 +1,105 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type JSONPathNode struct {
+	Label    string          `json:"first label"`
+	Children []*JSONPathNode `json:"children"`
+	Hidden   string          `json:"-"`
+}
+
+func TestGenerateJSONPaths(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Scan[User]()
+	paths := GenerateJSONPaths[User]()
+
+	expected := map[string]string{
+		"ID":                      "$.id",
+		"Profile":                 "$.profile",
+		"Profile.Address.City":    "$.profile.address.city",
+		"Orders.Items.ProductID":  "$.orders[*].items[*].product_id",
+		"Tags":                    "$.tags",
+		"Settings.Theme":          "$.theme",
+		"Settings.Metadata":       "$.metadata",
+		"Settings.Metadata.Value": "$.metadata.*.value",
+	}
+	for goPath, jsonPath := range expected {
+		if got := paths[goPath]; got != jsonPath {
+			t.Errorf("%s: expected %q, got %q", goPath, jsonPath, got)
+		}
+	}
+	if _, ok := paths["Settings"]; ok {
+		t.Error("expected inline embedded struct to have no path of its own")
+	}
+
+	t.Run("cycles and special names", func(t *testing.T) {
+		Scan[JSONPathNode]()
+		paths := GenerateJSONPaths[JSONPathNode]()
+
+		expected := map[string]string{
+			"Label":    "$['first label']",
+			"Children": "$.children",
+		}
+		if len(paths) != len(expected) {
+			t.Errorf("expected %v, got %v", expected, paths)
+		}
+		for goPath, jsonPath := range expected {
+			if got := paths[goPath]; got != jsonPath {
+				t.Errorf("%s: expected %q, got %q", goPath, jsonPath, got)
+			}
+		}
+	})
+}
+
+type SensitiveAccount struct {
+	Email    string            `json:"email" redact:"mask"`
+	Password string            `json:"-" encrypt:"bcrypt"`
+	Profile  *SensitiveProfile `json:"profile"`
+	Devices  []SensitiveDevice `json:"devices"`
+}
+
+type SensitiveProfile struct {
+	Name    string            `json:"name"`
+	SSN     string            `json:"ssn" encrypt:"pii"`
+	Account *SensitiveAccount `json:"account"`
+}
+
+type SensitiveDevice struct {
+	Serial string `json:"serial" audit:"pii"`
+}
+
+func TestSensitiveFieldPaths(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Tag("audit")
+	Scan[SensitiveAccount]()
+
+	t.Run("default tags", func(t *testing.T) {
+		expected := []string{"Email", "Password", "Profile.SSN"}
+		if got := SensitiveFieldPaths[SensitiveAccount](); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("custom tags", func(t *testing.T) {
+		expected := []string{"Devices.Serial"}
+		if got := SensitiveFieldPaths[SensitiveAccount]("audit"); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("from the other side of a cycle", func(t *testing.T) {
+		expected := []string{"Account.Email", "Account.Password", "SSN"}
+		if got := SensitiveFieldPaths[SensitiveProfile](); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+}
@@ -0,0 +1,305 @@
+package sentinel
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// jsonSchemaDialect is the JSON Schema draft emitted by GenerateJSONSchema.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Marshaler interfaces that change how encoding/json writes a value.
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// timeFQDN is the FQDN of time.Time, which encoding/json writes as an RFC 3339 string.
+const timeFQDN = "time.Time"
+
+// GenerateJSONSchema renders a JSON Schema (draft 2020-12) for T and every
+// cached type reachable from it. Each struct is emitted under $defs and the
+// root refers to T's definition. Fields that refer to another definition,
+// directly or through pointers, slices, arrays and maps, use $ref.
+//
+// Properties are named and required the way encoding/json writes them: the
+// json tag name (falling back to the field name), skipping fields excluded with
+// `json:"-"`, and requiring every field without omitempty or omitzero.
+// Scalars map to string, integer, number or boolean; slices and arrays to array;
+// maps to object with additionalProperties; pointers additionally allow null.
+// time.Time is a date-time string, registered scalar types are strings of
+// their format (see RegisterScalarType), registered enum types carry an enum
+// of their values (see RegisterEnum), and nullable wrappers that implement
+// json.Marshaler (such as pgtype.Text) are their nullable scalar, with UUID
+// wrappers such as pgtype.UUID written as nullable uuid strings; sql.Null
+// types do not and are written as objects. Embedded structs without a json name
+// are merged with allOf. Named pointer, slice, array and map types that contain
+// themselves accept any value where they recur. The desc tag becomes the
+// property description.
+//
+// Definitions are keyed by type name, or by FQDN with "/" replaced by "." when
+// two reachable types share a name. Returns ErrNotCached if T has not been
+// inspected or scanned; Scan T first to include its whole graph.
+func GenerateJSONSchema[T any]() ([]byte, error) {
+	var zero T
+	t, err := structType(reflect.TypeOf(zero))
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := LoadOrder(getFQDN(t))
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]Metadata, 0, len(steps))
+	for i, step := range steps {
+		metadata, exists := instance.cache.Get(step.FQDN)
+		if !exists {
+			// The root may have expired or been forgotten since LoadOrder
+			if i == 0 {
+				return nil, fmt.Errorf("%w: %s", ErrNotCached, step.FQDN)
+			}
+			continue
+		}
+		types = append(types, metadata)
+	}
+
+	g := &jsonSchemaGenerator{
+		names:     jsonSchemaDefNames(types),
+		expanding: make(map[reflect.Type]bool),
+	}
+	defs := make(map[string]any, len(types))
+	for _, metadata := range types {
+		defs[g.names[metadata.FQDN]] = g.typeSchema(metadata)
+	}
+
+	doc := map[string]any{
+		"$schema": jsonSchemaDialect,
+		"$ref":    g.ref(steps[0].FQDN),
+		"$defs":   defs,
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("sentinel: generate JSON schema: %w", err)
+	}
+	return data, nil
+}
+
+// jsonSchemaGenerator renders schemas for the types in one document.
+type jsonSchemaGenerator struct {
+	names     map[string]string     // FQDN -> $defs key
+	expanding map[reflect.Type]bool // Named non-struct types being rendered
+}
+
+// jsonSchemaDefNames assigns each type its $defs key.
+func jsonSchemaDefNames(types []Metadata) map[string]string {
+	counts := make(map[string]int, len(types))
+	for _, metadata := range types {
+		counts[metadata.TypeName]++
+	}
+
+	names := make(map[string]string, len(types))
+	for _, metadata := range types {
+		name := metadata.TypeName
+		if counts[name] > 1 {
+			name = strings.ReplaceAll(metadata.FQDN, "/", ".")
+		}
+		names[metadata.FQDN] = name
+	}
+	return names
+}
+
+// ref returns a $ref to a type's definition.
+func (g *jsonSchemaGenerator) ref(fqdn string) string {
+	return "#/$defs/" + g.names[fqdn]
+}
+
+// typeSchema renders the object schema of a struct type.
+func (g *jsonSchemaGenerator) typeSchema(metadata Metadata) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	var allOf []any
+
+	for _, field := range metadata.Fields {
+		// Promoted fields are covered by their embedding field
+		if field.JSONOmitted || len(field.Index) > 1 {
+			continue
+		}
+
+		// encoding/json promotes the fields of untagged embedded structs
+		if tag, _ := field.Tag("json"); field.Anonymous && tag.Name == "" && isStructOrStructPointer(field.ReflectType) {
+			allOf = append(allOf, g.embeddedRef(g.fieldSchema(field)))
+			continue
+		}
+
+		schema := g.fieldSchema(field)
+		if desc := field.Tags["desc"]; desc != "" {
+			schema["description"] = desc
+		}
+		properties[field.JSONName] = schema
+
+		tag, _ := field.Tag("json")
+		if !tag.Has("omitempty") && !tag.Has("omitzero") {
+			required = append(required, field.JSONName)
+		}
+	}
+
+	schema := map[string]any{
+		"title":      metadata.TypeName,
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	return schema
+}
+
+// embeddedRef returns the schema of an embedded struct, dropping the null
+// alternative added for embedded pointers.
+func (*jsonSchemaGenerator) embeddedRef(schema map[string]any) map[string]any {
+	if alternatives, ok := schema["anyOf"].([]any); ok {
+		return alternatives[0].(map[string]any)
+	}
+	if types, ok := schema["type"].([]string); ok {
+		schema["type"] = types[0]
+	}
+	return schema
+}
+
+// isStructOrStructPointer reports whether t is a struct or a pointer to one.
+func isStructOrStructPointer(t reflect.Type) bool {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// fieldSchema renders the schema of a field's value.
+func (g *jsonSchemaGenerator) fieldSchema(field FieldMetadata) map[string]any {
+	if field.ReflectType != nil {
+		return g.valueSchema(field.ReflectType)
+	}
+	// Fields without a ReflectType, such as virtual fields, fall back to scalars
+	if scalar, ok := scalarTypes[field.Type]; ok {
+		return g.valueSchema(scalar)
+	}
+	return map[string]any{}
+}
+
+// valueSchema renders the schema of a Go type as encoding/json writes it.
+func (g *jsonSchemaGenerator) valueSchema(t reflect.Type) map[string]any {
+	// Registered scalars such as UUIDs marshal as formatted strings
+	if format, ok := instance.scalarFormat(t); ok {
+		return map[string]any{"type": "string", "format": format}
+	}
+	// Registered enums are their kind, restricted to the allowed values
+	if values, ok := instance.enumValues(t); ok {
+		schema := g.valueSchema(scalarTypes[t.Kind().String()])
+		schema["enum"] = values
+		return schema
+	}
+
+	// Named pointers and collections can refer to themselves, as in
+	// type Tree map[string]Tree. Only structs are broken up by $ref, so a type
+	// already being expanded accepts any value.
+	if k := t.Kind(); t.Name() != "" && (k == reflect.Ptr || k == reflect.Slice || k == reflect.Array || k == reflect.Map) {
+		if g.expanding[t] {
+			return map[string]any{}
+		}
+		g.expanding[t] = true
+		defer delete(g.expanding, t)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullableSchema(g.valueSchema(t.Elem()))
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes []byte as base64
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": g.valueSchema(t.Elem())}
+
+	case reflect.Array:
+		return map[string]any{
+			"type":     "array",
+			"items":    g.valueSchema(t.Elem()),
+			"minItems": t.Len(),
+			"maxItems": t.Len(),
+		}
+
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.valueSchema(t.Elem())}
+
+	case reflect.Struct:
+		fqdn := getFQDN(t)
+		if _, ok := g.names[fqdn]; ok {
+			return map[string]any{"$ref": g.ref(fqdn)}
+		}
+		if fqdn == timeFQDN {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if implementsEither(t, jsonMarshalerType) {
+			// Nullable wrappers that marshal themselves write their value or null
+			if underlying, ok := instance.nullableUnderlying(t); ok {
+				if underlying == timeFQDN {
+					return nullableSchema(map[string]any{"type": "string", "format": "date-time"})
+				}
+				if underlying == uuidBytes {
+					return nullableSchema(map[string]any{"type": "string", "format": "uuid"})
+				}
+				if scalar, ok := scalarTypes[underlying]; ok {
+					return nullableSchema(g.valueSchema(scalar))
+				}
+			}
+			return map[string]any{}
+		}
+		if implementsEither(t, textMarshalerType) {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "object"}
+
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+
+	default:
+		// Interfaces accept any value; other kinds cannot be encoded
+		return map[string]any{}
+	}
+}
+
+// implementsEither reports whether t or *t implements iface.
+func implementsEither(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// nullableSchema extends a schema to also accept null.
+func nullableSchema(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		if values, ok := schema["enum"].([]any); ok {
+			schema["enum"] = append(slices.Clip(values), nil)
+		}
+		return schema
+	}
+	if len(schema) == 0 {
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
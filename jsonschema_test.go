@@ -0,0 +1,280 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type SchemaBase struct {
+	Version int `json:"version"`
+}
+
+type SchemaAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type SchemaAccount struct {
+	SchemaBase
+	ID      string                  `json:"id" desc:"Account identifier"`
+	Email   string                  `json:"email,omitempty"`
+	Age     int                     `json:"age"`
+	Score   float64                 `json:"score"`
+	Active  bool                    `json:"active"`
+	Tags    []string                `json:"tags"`
+	Counts  map[string]int          `json:"counts"`
+	Owner   *SchemaOwner            `json:"owner"`
+	Members []SchemaOwner           `json:"members"`
+	ByKey   map[string]*SchemaOwner `json:"by_key"`
+	Created time.Time               `json:"created"`
+	Deleted *time.Time              `json:"deleted,omitempty"`
+	Note    sql.NullString          `json:"note"`
+	Raw     []byte                  `json:"raw"`
+	Grid    [2]int                  `json:"grid"`
+	Secret  string                  `json:"-"`
+	Extra   any                     `json:"extra"`
+
+	// Options without a name: encoding/json still inlines the fields
+	SchemaAudit `json:",omitempty"`
+}
+
+type SchemaOwner struct {
+	Name    string         `json:"name"`
+	Account *SchemaAccount `json:"account,omitempty"`
+}
+
+// SchemaTree refers to itself without passing through a struct.
+type SchemaTree map[string]SchemaTree
+
+type SchemaForest struct {
+	Root SchemaTree `json:"root"`
+}
+
+// schemaDocument unmarshals generated schema JSON for inspection.
+func schemaDocument(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return doc
+}
+
+// schemaPath walks nested objects by key.
+func schemaPath(t *testing.T, node any, keys ...string) any {
+	t.Helper()
+	for _, key := range keys {
+		object, ok := node.(map[string]any)
+		if !ok {
+			t.Fatalf("expected object at %q, got %v", key, node)
+		}
+		node = object[key]
+	}
+	return node
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("requires cached root", func(t *testing.T) {
+		if _, err := GenerateJSONSchema[SchemaAccount](); !errors.Is(err, ErrNotCached) {
+			t.Errorf("expected ErrNotCached, got %v", err)
+		}
+	})
+
+	t.Run("rejects non-struct types", func(t *testing.T) {
+		if _, err := GenerateJSONSchema[string](); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("expected ErrNotStruct, got %v", err)
+		}
+	})
+
+	Scan[SchemaAccount]()
+	data, err := GenerateJSONSchema[SchemaAccount]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc := schemaDocument(t, data)
+	account := schemaPath(t, doc, "$defs", "SchemaAccount")
+	property := func(name string) any {
+		return schemaPath(t, account, "properties", name)
+	}
+
+	t.Run("document root", func(t *testing.T) {
+		if doc["$schema"] != jsonSchemaDialect {
+			t.Errorf("unexpected $schema: %v", doc["$schema"])
+		}
+		if doc["$ref"] != "#/$defs/SchemaAccount" {
+			t.Errorf("unexpected root $ref: %v", doc["$ref"])
+		}
+		defs := doc["$defs"].(map[string]any)
+		for _, name := range []string{"SchemaAccount", "SchemaBase", "SchemaAudit", "SchemaOwner"} {
+			if _, ok := defs[name]; !ok {
+				t.Errorf("expected definition %s, got %v", name, defs)
+			}
+		}
+	})
+
+	t.Run("scalar types", func(t *testing.T) {
+		expected := map[string]any{
+			"id":     "string",
+			"age":    "integer",
+			"score":  "number",
+			"active": "boolean",
+		}
+		for name, typ := range expected {
+			if got := schemaPath(t, property(name), "type"); got != typ {
+				t.Errorf("%s: expected type %v, got %v", name, typ, got)
+			}
+		}
+		if got := schemaPath(t, property("id"), "description"); got != "Account identifier" {
+			t.Errorf("expected desc tag as description, got %v", got)
+		}
+	})
+
+	t.Run("collections and maps", func(t *testing.T) {
+		if got := schemaPath(t, property("tags"), "items", "type"); got != "string" {
+			t.Errorf("expected string items, got %v", got)
+		}
+		if got := schemaPath(t, property("counts"), "additionalProperties", "type"); got != "integer" {
+			t.Errorf("expected integer map values, got %v", got)
+		}
+		if got := schemaPath(t, property("members"), "items", "$ref"); got != "#/$defs/SchemaOwner" {
+			t.Errorf("expected collection $ref, got %v", got)
+		}
+		if got := schemaPath(t, property("grid"), "maxItems"); got != float64(2) {
+			t.Errorf("expected fixed-size array, got %v", got)
+		}
+		if got := schemaPath(t, property("raw"), "contentEncoding"); got != "base64" {
+			t.Errorf("expected base64 bytes, got %v", got)
+		}
+	})
+
+	t.Run("pointers are nullable", func(t *testing.T) {
+		alternatives, ok := schemaPath(t, property("owner"), "anyOf").([]any)
+		if !ok || len(alternatives) != 2 {
+			t.Fatalf("expected anyOf for pointer reference, got %v", property("owner"))
+		}
+		if got := schemaPath(t, alternatives[0], "$ref"); got != "#/$defs/SchemaOwner" {
+			t.Errorf("expected reference $ref, got %v", got)
+		}
+		if got := schemaPath(t, alternatives[1], "type"); got != "null" {
+			t.Errorf("expected null alternative, got %v", got)
+		}
+
+		additional := schemaPath(t, property("by_key"), "additionalProperties", "anyOf").([]any)
+		if got := schemaPath(t, additional[0], "$ref"); got != "#/$defs/SchemaOwner" {
+			t.Errorf("expected map $ref, got %v", got)
+		}
+
+		types := schemaPath(t, property("deleted"), "type")
+		if !reflect.DeepEqual(types, []any{"string", "null"}) {
+			t.Errorf("expected nullable date-time, got %v", types)
+		}
+	})
+
+	t.Run("special structs", func(t *testing.T) {
+		if got := schemaPath(t, property("created"), "format"); got != "date-time" {
+			t.Errorf("expected date-time, got %v", got)
+		}
+		// sql.NullString has no MarshalJSON, so encoding/json writes an object
+		if got := schemaPath(t, property("note"), "type"); got != "object" {
+			t.Errorf("expected object for sql.NullString, got %v", got)
+		}
+		if got := property("extra"); !reflect.DeepEqual(got, map[string]any{}) {
+			t.Errorf("expected empty schema for interface, got %v", got)
+		}
+	})
+
+	t.Run("json tags", func(t *testing.T) {
+		properties := schemaPath(t, account, "properties").(map[string]any)
+		if _, ok := properties["Secret"]; ok {
+			t.Error("expected excluded field to be skipped")
+		}
+		if _, ok := properties["-"]; ok {
+			t.Error("expected excluded field to be skipped")
+		}
+
+		required := map[string]bool{}
+		for _, name := range schemaPath(t, account, "required").([]any) {
+			required[name.(string)] = true
+		}
+		if !required["id"] || !required["age"] || !required["owner"] {
+			t.Errorf("expected fields without omitempty to be required, got %v", required)
+		}
+		if required["email"] || required["deleted"] {
+			t.Errorf("expected omitempty fields to be optional, got %v", required)
+		}
+	})
+
+	t.Run("embedded structs merge with allOf", func(t *testing.T) {
+		allOf, ok := schemaPath(t, account, "allOf").([]any)
+		if !ok || len(allOf) != 2 {
+			t.Fatalf("expected allOf with the embedded base and audit, got %v", account)
+		}
+		if got := schemaPath(t, allOf[0], "$ref"); got != "#/$defs/SchemaBase" {
+			t.Errorf("expected embedded $ref, got %v", got)
+		}
+		if got := schemaPath(t, allOf[1], "$ref"); got != "#/$defs/SchemaAudit" {
+			t.Errorf("expected embedded $ref for the omitempty embedding, got %v", got)
+		}
+		properties := schemaPath(t, account, "properties").(map[string]any)
+		for _, name := range []string{"SchemaBase", "SchemaAudit"} {
+			if _, ok := properties[name]; ok {
+				t.Errorf("expected embedded struct %s not to be a property", name)
+			}
+		}
+	})
+
+	t.Run("cycles use references", func(t *testing.T) {
+		owner := schemaPath(t, doc, "$defs", "SchemaOwner", "properties", "account", "anyOf").([]any)
+		if got := schemaPath(t, owner[0], "$ref"); got != "#/$defs/SchemaAccount" {
+			t.Errorf("expected back-reference $ref, got %v", got)
+		}
+	})
+
+	t.Run("self-referencing named types", func(t *testing.T) {
+		Inspect[SchemaForest]()
+		data, err := GenerateJSONSchema[SchemaForest]()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		root := schemaPath(t, schemaDocument(t, data), "$defs", "SchemaForest", "properties", "root")
+		expected := map[string]any{"type": "object", "additionalProperties": map[string]any{}}
+		if !reflect.DeepEqual(root, expected) {
+			t.Errorf("expected recursion to stop at the repeated type, got %v", root)
+		}
+	})
+
+	t.Run("deterministic output", func(t *testing.T) {
+		again, err := GenerateJSONSchema[SchemaAccount]()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(again) != string(data) {
+			t.Error("expected identical output across runs")
+		}
+	})
+}
+
+func TestJSONSchemaDefNames(t *testing.T) {
+	names := jsonSchemaDefNames([]Metadata{
+		{FQDN: "github.com/acme/billing.Account", TypeName: "Account"},
+		{FQDN: "github.com/acme/identity.Account", TypeName: "Account"},
+		{FQDN: "github.com/acme/identity.User", TypeName: "User"},
+	})
+
+	expected := map[string]string{
+		"github.com/acme/billing.Account":  "github.com.acme.billing.Account",
+		"github.com/acme/identity.Account": "github.com.acme.identity.Account",
+		"github.com/acme/identity.User":    "User",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
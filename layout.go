@@ -0,0 +1,59 @@
+package sentinel
+
+import "reflect"
+
+// LayoutReport describes the memory layout of a struct and the padding the
+// compiler inserts to align its fields.
+type LayoutReport struct {
+	FQDN    string          `json:"fqdn"`
+	Padding []LayoutPadding `json:"padding,omitempty"` // Holes in field order, including trailing padding
+	Size    uintptr         `json:"size"`
+	Align   uintptr         `json:"align"`
+	Wasted  uintptr         `json:"wasted"` // Total padding bytes
+}
+
+// LayoutPadding is a run of padding bytes following a field.
+type LayoutPadding struct {
+	After  string  `json:"after"`  // Field the padding follows
+	Offset uintptr `json:"offset"` // Offset of the first padding byte
+	Size   uintptr `json:"size"`
+}
+
+// AnalyzeLayout reports the padding between the fields of a struct and after
+// its last field. Every declared field takes part, including unexported and
+// blank fields that Metadata.Fields omits, so the report matches the layout
+// the compiler chose. Padding requires meta.ReflectType; metadata without it,
+// such as metadata parsed from a schema document, reports only its size and
+// alignment.
+func AnalyzeLayout(meta Metadata) LayoutReport {
+	report := LayoutReport{
+		FQDN:  meta.FQDN,
+		Size:  meta.Size,
+		Align: meta.Align,
+	}
+
+	t := meta.ReflectType
+	if t == nil || t.Kind() != reflect.Struct {
+		return report
+	}
+
+	var end uintptr
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Offset > end && i > 0 {
+			report.addPadding(t.Field(i-1).Name, end, field.Offset-end)
+		}
+		end = field.Offset + field.Type.Size()
+	}
+	if t.NumField() > 0 && t.Size() > end {
+		report.addPadding(t.Field(t.NumField()-1).Name, end, t.Size()-end)
+	}
+
+	return report
+}
+
+// addPadding records a padding hole.
+func (r *LayoutReport) addPadding(after string, offset, size uintptr) {
+	r.Padding = append(r.Padding, LayoutPadding{After: after, Offset: offset, Size: size})
+	r.Wasted += size
+}
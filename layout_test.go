@@ -0,0 +1,88 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+// LayoutPadded has deliberate padding holes after A and C and at the end.
+type LayoutPadded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+	E int64
+	F bool
+}
+
+func TestLayoutMetadata(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var zero LayoutPadded
+	metadata := Inspect[LayoutPadded]()
+
+	if metadata.Size != unsafe.Sizeof(zero) || metadata.Align != unsafe.Alignof(zero) {
+		t.Errorf("expected size %d align %d, got size %d align %d",
+			unsafe.Sizeof(zero), unsafe.Alignof(zero), metadata.Size, metadata.Align)
+	}
+
+	expected := map[string]uintptr{
+		"A": unsafe.Offsetof(zero.A),
+		"B": unsafe.Offsetof(zero.B),
+		"C": unsafe.Offsetof(zero.C),
+		"D": unsafe.Offsetof(zero.D),
+		"E": unsafe.Offsetof(zero.E),
+		"F": unsafe.Offsetof(zero.F),
+	}
+	for _, field := range metadata.Fields {
+		if field.Offset != expected[field.Name] {
+			t.Errorf("field %s: expected offset %d, got %d", field.Name, expected[field.Name], field.Offset)
+		}
+	}
+
+	t.Run("pointer types report the element layout", func(t *testing.T) {
+		pointer := Inspect[*LayoutPadded]()
+		if pointer.Size != metadata.Size || pointer.Align != metadata.Align {
+			t.Errorf("expected element layout, got size %d align %d", pointer.Size, pointer.Align)
+		}
+	})
+}
+
+func TestAnalyzeLayout(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var zero LayoutPadded
+	report := AnalyzeLayout(Inspect[LayoutPadded]())
+
+	var expected []LayoutPadding
+	var wasted uintptr
+	hole := func(after string, end, next uintptr) {
+		if next > end {
+			expected = append(expected, LayoutPadding{After: after, Offset: end, Size: next - end})
+			wasted += next - end
+		}
+	}
+	hole("A", unsafe.Offsetof(zero.A)+unsafe.Sizeof(zero.A), unsafe.Offsetof(zero.B))
+	hole("C", unsafe.Offsetof(zero.C)+unsafe.Sizeof(zero.C), unsafe.Offsetof(zero.D))
+	hole("D", unsafe.Offsetof(zero.D)+unsafe.Sizeof(zero.D), unsafe.Offsetof(zero.E))
+	hole("F", unsafe.Offsetof(zero.F)+unsafe.Sizeof(zero.F), unsafe.Sizeof(zero))
+
+	if !reflect.DeepEqual(report.Padding, expected) {
+		t.Errorf("expected padding %+v, got %+v", expected, report.Padding)
+	}
+	if report.Wasted != wasted || wasted == 0 {
+		t.Errorf("expected %d wasted bytes, got %d", wasted, report.Wasted)
+	}
+
+	t.Run("without a reflect type", func(t *testing.T) {
+		report := AnalyzeLayout(Metadata{FQDN: "x.Parsed", Size: 16, Align: 8})
+		if report.Size != 16 || report.Align != 8 || report.Padding != nil {
+			t.Errorf("expected size and alignment only, got %+v", report)
+		}
+	})
+}
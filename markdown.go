@@ -0,0 +1,117 @@
+package sentinel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GenerateMarkdown renders documentation for every cached type as Markdown.
+// Each type gets a section with a field table and its outgoing relationships.
+// Types are ordered by FQDN so the output is stable across runs.
+func GenerateMarkdown() string {
+	schema := instance.cache.All()
+
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	return renderMarkdown(schema, fqdns)
+}
+
+// GenerateMarkdownFromRoot scans T and renders documentation for T and every
+// type reachable from it, in breadth-first order from the root.
+// Panics if T is not a struct type.
+func GenerateMarkdownFromRoot[T any]() string {
+	root := Scan[T]()
+
+	steps, err := LoadOrder(root.FQDN)
+	if err != nil {
+		return ""
+	}
+
+	fqdns := make([]string, 0, len(steps))
+	for _, step := range steps {
+		fqdns = append(fqdns, step.FQDN)
+	}
+
+	return renderMarkdown(instance.cache.All(), fqdns)
+}
+
+// renderMarkdown writes a section for each listed type present in the schema.
+func renderMarkdown(schema map[string]Metadata, fqdns []string) string {
+	var b strings.Builder
+
+	for _, fqdn := range fqdns {
+		metadata, exists := schema[fqdn]
+		if !exists {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		writeMarkdownType(&b, &metadata)
+	}
+
+	return b.String()
+}
+
+// writeMarkdownType writes the section for a single type.
+func writeMarkdownType(b *strings.Builder, metadata *Metadata) {
+	fmt.Fprintf(b, "## %s\n\n", metadata.TypeName)
+	fmt.Fprintf(b, "`%s`\n\n", metadata.FQDN)
+
+	if len(metadata.Fields) == 0 {
+		b.WriteString("_No exported fields._\n")
+	} else {
+		b.WriteString("| Name | JSON | Type | Tags | Description |\n")
+		b.WriteString("| ---- | ---- | ---- | ---- | ----------- |\n")
+		for _, field := range metadata.Fields {
+			jsonName := field.JSONName
+			if field.JSONOmitted {
+				jsonName = "-"
+			}
+			fmt.Fprintf(b, "| %s | %s | `%s` | %s | %s |\n",
+				field.Name,
+				escapeMarkdownCell(jsonName),
+				escapeMarkdownCell(field.Type),
+				escapeMarkdownCell(formatMarkdownTags(field.Tags)),
+				escapeMarkdownCell(field.Tags["desc"]),
+			)
+		}
+	}
+
+	if len(metadata.Relationships) > 0 {
+		b.WriteString("\n### Relationships\n\n")
+		for _, rel := range metadata.Relationships {
+			fmt.Fprintf(b, "- `%s` → `%s` (%s)\n", rel.Field, rel.To, rel.Kind)
+		}
+	}
+}
+
+// formatMarkdownTags renders tags as sorted `name:"value"` pairs, excluding
+// json and desc which have their own columns.
+func formatMarkdownTags(tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		if name == "json" || name == "desc" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("`%s:%q`", name, tags[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// escapeMarkdownCell escapes characters that would break a table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
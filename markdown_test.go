@@ -0,0 +1,100 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"strings"
+	"testing"
+)
+
+type MarkdownDoc struct {
+	ID    string `json:"id" desc:"Unique identifier"`
+	Email string `json:"email,omitempty" validate:"required|email" encrypt:"pii"`
+	Notes string
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	instance.cache.Clear()
+
+	Inspect[MarkdownDoc]()
+	Inspect[Address]()
+
+	output := GenerateMarkdown()
+
+	t.Run("heading per cached type", func(t *testing.T) {
+		for _, heading := range []string{"## MarkdownDoc\n", "## Address\n"} {
+			if !strings.Contains(output, heading) {
+				t.Errorf("expected heading %q in output", heading)
+			}
+		}
+	})
+
+	t.Run("types are sorted by FQDN", func(t *testing.T) {
+		if strings.Index(output, "## Address") > strings.Index(output, "## MarkdownDoc") {
+			t.Error("expected Address section before MarkdownDoc")
+		}
+	})
+
+	t.Run("row per field", func(t *testing.T) {
+		rows := []string{
+			"| ID | id | `string` |  | Unique identifier |",
+			"| Email | email | `string` | `encrypt:\"pii\"` `validate:\"required\\|email\"` |  |",
+			"| Notes | Notes | `string` |  |  |",
+		}
+		for _, row := range rows {
+			if !strings.Contains(output, row) {
+				t.Errorf("expected row %q in output:\n%s", row, output)
+			}
+		}
+	})
+
+	t.Run("omitted fields have no JSON name", func(t *testing.T) {
+		SetUnexportedFields(true)
+		defer SetUnexportedFields(false)
+
+		type MarkdownSecret struct {
+			secret string //nolint:unused // Unexported fields are skipped by encoding/json
+		}
+		Inspect[MarkdownSecret]()
+
+		if row := "| secret | - | `string` |  |  |"; !strings.Contains(GenerateMarkdown(), row) {
+			t.Errorf("expected row %q in output", row)
+		}
+	})
+}
+
+func TestGenerateMarkdownFromRoot(t *testing.T) {
+	instance.cache.Clear()
+	Inspect[MarkdownDoc]()
+
+	output := GenerateMarkdownFromRoot[User]()
+
+	t.Run("includes reachable types", func(t *testing.T) {
+		for _, name := range []string{"User", "Profile", "Address", "Order", "OrderItem", "Settings", "Data"} {
+			if !strings.Contains(output, "## "+name+"\n") {
+				t.Errorf("expected section for %s", name)
+			}
+		}
+	})
+
+	t.Run("excludes unrelated types", func(t *testing.T) {
+		if strings.Contains(output, "## MarkdownDoc") {
+			t.Error("expected unrelated type to be excluded")
+		}
+	})
+
+	t.Run("root comes first", func(t *testing.T) {
+		if !strings.HasPrefix(output, "## User\n") {
+			t.Errorf("expected output to start with root section, got %q", output[:20])
+		}
+	})
+
+	t.Run("lists relationships", func(t *testing.T) {
+		if !strings.Contains(output, "### Relationships") {
+			t.Error("expected relationships subsection")
+		}
+		if !strings.Contains(output, "- `Profile` → `github.com/zoobz-io/sentinel.Profile` (reference)") {
+			t.Errorf("expected Profile relationship in output:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package sentinel
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Marker registers a marker interface under a name. During extraction, every
+// type implementing the interface (with a value or pointer receiver) has the
+// name added to Metadata.Markers. The interface type is usually obtained with
+// reflect.TypeOf((*Entity)(nil)).Elem(); non-interface types are ignored.
+// Only affects types extracted after the call.
+func Marker(name string, iface reflect.Type) {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return
+	}
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if instance.markers == nil {
+		instance.markers = make(map[string]reflect.Type)
+	}
+	instance.markers[name] = iface
+}
+
+// GetMarkers returns the names of the registered marker interfaces T implements.
+// Panics if T is not a struct type.
+func GetMarkers[T any]() []string {
+	return Inspect[T]().Markers
+}
+
+// HasMarker reports whether T implements the marker interface registered under name.
+// Panics if T is not a struct type.
+func HasMarker[T any](name string) bool {
+	for _, marker := range GetMarkers[T]() {
+		if marker == name {
+			return true
+		}
+	}
+	return false
+}
+
+// extractMarkers returns the sorted names of registered marker interfaces the type implements.
+func (s *Sentinel) extractMarkers(t reflect.Type) []string {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	var markers []string
+	ptr := reflect.PointerTo(t)
+	for name, iface := range s.markers {
+		if t.Implements(iface) || ptr.Implements(iface) {
+			markers = append(markers, name)
+		}
+	}
+
+	sort.Strings(markers)
+	return markers
+}
@@ -0,0 +1,85 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Entity interface {
+	EntityID() string
+}
+
+type Aggregate interface {
+	AggregateRoot()
+}
+
+type MarkedEntity struct {
+	ID string
+}
+
+func (m MarkedEntity) EntityID() string { return m.ID }
+
+type MarkedAggregate struct {
+	ID string
+}
+
+func (m *MarkedAggregate) EntityID() string { return m.ID }
+
+func (*MarkedAggregate) AggregateRoot() {}
+
+type Unmarked struct {
+	ID string
+}
+
+func TestMarkers(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Marker("entity", reflect.TypeOf((*Entity)(nil)).Elem())
+	Marker("aggregate", reflect.TypeOf((*Aggregate)(nil)).Elem())
+
+	t.Run("value receiver", func(t *testing.T) {
+		markers := GetMarkers[MarkedEntity]()
+		if len(markers) != 1 || markers[0] != "entity" {
+			t.Errorf("expected [entity], got %v", markers)
+		}
+	})
+
+	t.Run("pointer receiver", func(t *testing.T) {
+		markers := GetMarkers[MarkedAggregate]()
+		if len(markers) != 2 || markers[0] != "aggregate" || markers[1] != "entity" {
+			t.Errorf("expected [aggregate entity], got %v", markers)
+		}
+	})
+
+	t.Run("HasMarker", func(t *testing.T) {
+		if !HasMarker[MarkedAggregate]("aggregate") {
+			t.Error("expected MarkedAggregate to have aggregate marker")
+		}
+		if HasMarker[MarkedEntity]("aggregate") {
+			t.Error("expected MarkedEntity not to have aggregate marker")
+		}
+	})
+
+	t.Run("no markers", func(t *testing.T) {
+		if markers := GetMarkers[Unmarked](); len(markers) != 0 {
+			t.Errorf("expected no markers, got %v", markers)
+		}
+	})
+
+	t.Run("non-interface types are ignored", func(t *testing.T) {
+		Marker("invalid", reflect.TypeOf(""))
+		Marker("nil", nil)
+
+		instance.configMutex.RLock()
+		defer instance.configMutex.RUnlock()
+		if _, ok := instance.markers["invalid"]; ok {
+			t.Error("expected non-interface marker to be ignored")
+		}
+		if _, ok := instance.markers["nil"]; ok {
+			t.Error("expected nil marker to be ignored")
+		}
+	})
+}
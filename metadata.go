@@ -1,7 +1,10 @@
 package sentinel
 
 import (
+	"cmp"
 	"reflect"
+	"slices"
+	"strings"
 )
 
 // FieldKind represents the category of a field's type.
@@ -19,22 +22,175 @@ const (
 
 // Metadata contains comprehensive information about a user model.
 type Metadata struct {
-	ReflectType   reflect.Type       `json:"-"`
-	FQDN          string             `json:"fqdn"`         // Fully qualified type name (e.g., "github.com/app/models.User")
-	TypeName      string             `json:"type_name"`    // Simple type name (e.g., "User")
-	PackageName   string             `json:"package_name"` // Package path (e.g., "github.com/app/models")
-	Fields        []FieldMetadata    `json:"fields"`
-	Relationships []TypeRelationship `json:"relationships,omitempty"`
+	ReflectType     reflect.Type        `json:"-"`
+	TypeTags        map[string]string   `json:"type_tags,omitempty"`      // Tags declared on blank `_` marker fields
+	FieldGroups     map[string][]string `json:"field_groups,omitempty"`   // Group tag -> field names in declaration order ("" = ungrouped)
+	FQDN            string              `json:"fqdn"`                     // Fully qualified type name (e.g., "github.com/app/models.User")
+	TypeName        string              `json:"type_name"`                // Simple type name (e.g., "User")
+	PackageName     string              `json:"package_name"`             // Package path (e.g., "github.com/app/models")
+	Doc             string              `json:"doc,omitempty"`            // Type doc comment (see SetSourceComments)
+	GenericBase     string              `json:"generic_base,omitempty"`   // Base name of an instantiated generic (e.g., "Page" for Page[Order])
+	TypeArguments   []string            `json:"type_arguments,omitempty"` // Type arguments of an instantiated generic (e.g., ["github.com/app.Order"])
+	Fields          []FieldMetadata     `json:"fields"`
+	Relationships   []TypeRelationship  `json:"relationships,omitempty"`
+	Markers         []string            `json:"markers,omitempty"`           // Registered marker interfaces the type implements
+	Warnings        []string            `json:"warnings,omitempty"`          // Problems found while scanning from this type (see SetMaxDepth)
+	Size            uintptr             `json:"size,omitempty"`              // Size in bytes (reflect.Type.Size); platform-dependent
+	Align           uintptr             `json:"align,omitempty"`             // Alignment in bytes (reflect.Type.Align); platform-dependent
+	TotalFieldCount int                 `json:"total_field_count,omitempty"` // Exported field count before truncation (set when Truncated)
+	Truncated       bool                `json:"truncated,omitempty"`         // Fields was capped by SetMaxFields
+	JoinTable       bool                `json:"join_table,omitempty"`        // Links two types many-to-many (see SetJoinDetection)
 }
 
 // FieldMetadata captures field-level information and all struct tags.
 type FieldMetadata struct {
-	ReflectType reflect.Type      `json:"-"`
-	Tags        map[string]string `json:"tags,omitempty"`
-	Name        string            `json:"name"`
-	Type        string            `json:"type"`
-	Kind        FieldKind         `json:"kind"`
-	Index       []int             `json:"index"`
+	ReflectType      reflect.Type      `json:"-"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	InterfaceMethods []MethodSignature `json:"interface_methods,omitempty"` // Exported method set of interface fields
+	SerializedNames  map[string]string `json:"serialized_names,omitempty"`  // Codec tag -> serialized name (see RegisterCodecTag)
+	EnumValues       []string          `json:"enum_values,omitempty"`       // Allowed values of a registered enum type (see RegisterEnum)
+	Name             string            `json:"name"`
+	JSONName         string            `json:"json_name"` // Effective encoding/json key ("" when JSONOmitted)
+	Type             string            `json:"type"`
+	Underlying       string            `json:"underlying,omitempty"`       // Wrapped scalar type of a nullable wrapper (see RegisterNullableType)
+	Format           string            `json:"format,omitempty"`           // Schema format of a registered scalar type, e.g. "uuid" (see RegisterScalarType)
+	Doc              string            `json:"doc,omitempty"`              // Field doc or line comment (see SetSourceComments)
+	DeprecationNote  string            `json:"deprecation_note,omitempty"` // Value of the deprecated tag, or the "Deprecated:" doc paragraph
+	Kind             FieldKind         `json:"kind"`
+	Index            []int             `json:"index"`
+	ArrayLen         int               `json:"array_len,omitempty"`    // Fixed length for array fields (0 for slices and other kinds)
+	Offset           uintptr           `json:"offset,omitempty"`       // Byte offset within the declaring struct (the embedded struct for promoted fields); platform-dependent
+	Anonymous        bool              `json:"anonymous,omitempty"`    // Embedded (anonymous) field
+	Exported         bool              `json:"exported"`               // Declared with an exported name (see SetUnexportedFields)
+	Virtual          bool              `json:"virtual,omitempty"`      // Registered with RegisterVirtualField; not declared on the struct
+	JSONOmitted      bool              `json:"json_omitted,omitempty"` // Excluded from JSON with `json:"-"`
+	Deprecated       bool              `json:"deprecated,omitempty"`   // Has a deprecated tag or a "Deprecated:" doc paragraph
+	Nullable         bool              `json:"nullable,omitempty"`     // Can be nil (pointer, slice, map, interface, chan, func) or is a nullable wrapper such as sql.NullString
+}
+
+// MethodSignature describes a method of an interface field.
+type MethodSignature struct {
+	Name    string   `json:"name"`
+	Params  []string `json:"params,omitempty"`  // Parameter types; a variadic last parameter is written ...T
+	Results []string `json:"results,omitempty"` // Result types
+}
+
+// interfaceMethods returns the exported method set of an interface type,
+// including methods of embedded interfaces, sorted by name. Returns nil for
+// other kinds and for interfaces without exported methods, such as any.
+func interfaceMethods(t reflect.Type) []MethodSignature {
+	if t.Kind() != reflect.Interface {
+		return nil
+	}
+
+	var methods []MethodSignature
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if !method.IsExported() {
+			continue
+		}
+
+		signature := MethodSignature{Name: method.Name}
+		for j := 0; j < method.Type.NumIn(); j++ {
+			param := method.Type.In(j).String()
+			if method.Type.IsVariadic() && j == method.Type.NumIn()-1 {
+				param = "..." + method.Type.In(j).Elem().String()
+			}
+			signature.Params = append(signature.Params, param)
+		}
+		for j := 0; j < method.Type.NumOut(); j++ {
+			signature.Results = append(signature.Results, method.Type.Out(j).String())
+		}
+		methods = append(methods, signature)
+	}
+	return methods
+}
+
+// Group returns the fields whose group tag is name, in declaration order.
+// Use "" for fields without a group tag.
+func (m Metadata) Group(name string) []FieldMetadata {
+	var fields []FieldMetadata
+	for _, field := range m.Fields {
+		if field.Tags["group"] == name {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FieldByName returns the field with the given name and whether it exists.
+func (m Metadata) FieldByName(name string) (FieldMetadata, bool) {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return FieldMetadata{}, false
+}
+
+// FieldByTag returns the fields whose tag equals value, in declaration order.
+// The raw tag value is compared, options included.
+func (m Metadata) FieldByTag(tag, value string) []FieldMetadata {
+	var fields []FieldMetadata
+	for _, field := range m.Fields {
+		if v, ok := field.Tags[tag]; ok && v == value {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FieldSort is a canonical ordering of fields for SortedFields.
+type FieldSort string
+
+// FieldSort constants for SortedFields.
+const (
+	ByIndex    FieldSort = "index"     // Index path, i.e. declaration order with promoted fields after their embedding field
+	ByName     FieldSort = "name"      // Go field name
+	ByJSONName FieldSort = "json_name" // JSONName, then Name; fields omitted from JSON sort last
+)
+
+// SortedFields returns a copy of Fields in the given order, leaving the
+// metadata untouched. Fields without an Index, such as virtual fields, sort
+// last under ByIndex. Ties keep declaration order, and an unknown FieldSort
+// returns the fields in declaration order.
+func (m Metadata) SortedFields(by FieldSort) []FieldMetadata {
+	fields := slices.Clone(m.Fields)
+	switch by {
+	case ByIndex:
+		slices.SortStableFunc(fields, func(a, b FieldMetadata) int {
+			if (len(a.Index) == 0) != (len(b.Index) == 0) {
+				return cmp.Compare(len(b.Index), len(a.Index))
+			}
+			return slices.Compare(a.Index, b.Index)
+		})
+	case ByName:
+		slices.SortStableFunc(fields, func(a, b FieldMetadata) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	case ByJSONName:
+		slices.SortStableFunc(fields, func(a, b FieldMetadata) int {
+			if a.JSONOmitted != b.JSONOmitted {
+				if a.JSONOmitted {
+					return 1
+				}
+				return -1
+			}
+			return cmp.Or(strings.Compare(a.JSONName, b.JSONName), strings.Compare(a.Name, b.Name))
+		})
+	}
+	return fields
+}
+
+// declaredFields returns the fields declared on the struct, skipping virtual fields.
+func declaredFields(fields []FieldMetadata) []FieldMetadata {
+	declared := make([]FieldMetadata, 0, len(fields))
+	for _, field := range fields {
+		if !field.Virtual {
+			declared = append(declared, field)
+		}
+	}
+	return declared
 }
 
 // getFQDN returns the fully qualified type name (package path + type name).
@@ -62,6 +218,36 @@ func getTypeName(t reflect.Type) string {
 	return t.Name()
 }
 
+// parseGenericName splits the name of an instantiated generic type into its base
+// name and type arguments, e.g. "Page[github.com/app.Order,int]" becomes "Page"
+// and ["github.com/app.Order", "int"]. Non-generic names return no arguments.
+func parseGenericName(name string) (string, []string) {
+	open := strings.IndexByte(name, '[')
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return name, nil
+	}
+
+	var args []string
+	depth := 0
+	start := open + 1
+	for i := start; i < len(name)-1; i++ {
+		switch name[i] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(name[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(name[start:len(name)-1]))
+
+	return name[:open], args
+}
+
 // getFieldKind determines the FieldKind category from a reflect.Type.
 func getFieldKind(t reflect.Type) FieldKind {
 	if t == nil {
@@ -85,19 +271,56 @@ func getFieldKind(t reflect.Type) FieldKind {
 	}
 }
 
+// isNilable reports whether values of a type can be nil.
+// Arrays and structs are values and never nil, even when they contain pointers.
+func isNilable(t reflect.Type) bool {
+	if t == nil {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+		return true
+	default:
+		return false
+	}
+}
+
 // TypeRelationship represents a relationship between two types.
 type TypeRelationship struct {
-	From      string `json:"from"`       // Source type name
-	To        string `json:"to"`         // Target type name
-	Field     string `json:"field"`      // Field creating the relationship
-	Kind      string `json:"kind"`       // "reference", "collection", "embedding", "map"
-	ToPackage string `json:"to_package"` // Target type's package path
+	From           string   `json:"from"`                      // Source type name
+	To             string   `json:"to"`                        // Target type name
+	Field          string   `json:"field"`                     // Field creating the relationship
+	Kind           string   `json:"kind"`                      // "reference", "collection", "embedding", "map"
+	Cardinality    string   `json:"cardinality"`               // How many targets the field holds: "one" or "many"
+	ToPackage      string   `json:"to_package"`                // Target type's package path
+	GenericBase    string   `json:"generic_base,omitempty"`    // Base name of an embedded generic (e.g., "Base" for Base[ID])
+	TypeArguments  []string `json:"type_arguments,omitempty"`  // Type arguments of an embedded generic (e.g., ["github.com/app.ID"])
+	ViaPointer     bool     `json:"via_pointer,omitempty"`     // Target held through a pointer (*T, []*T, map[K]*T)
+	EmbeddedInline bool     `json:"embedded_inline,omitempty"` // Embedded fields are promoted into the parent JSON object (no json name)
+	Mutual         bool     `json:"mutual,omitempty"`          // Reference whose target references the source back (one-to-one)
 }
 
 // RelationshipKind constants for different relationship types.
 const (
-	RelationshipReference  = "reference"  // Direct field reference (e.g., Profile *Profile)
-	RelationshipCollection = "collection" // Slice/array of types (e.g., Orders []Order)
-	RelationshipEmbedding  = "embedding"  // Anonymous field embedding
-	RelationshipMap        = "map"        // Map with struct values
+	RelationshipReference  = "reference"    // Direct field reference (e.g., Profile *Profile)
+	RelationshipCollection = "collection"   // Slice/array of types (e.g., Orders []Order)
+	RelationshipEmbedding  = "embedding"    // Anonymous field embedding
+	RelationshipMap        = "map"          // Map with struct values
+	RelationshipManyToMany = "many-to-many" // Derived link between the endpoints of a join table
 )
+
+// Cardinality constants for how many targets a relationship holds.
+const (
+	CardinalityOne  = "one"  // References and embeddings
+	CardinalityMany = "many" // Collections, maps and derived many-to-many links
+)
+
+// relationshipCardinality returns the cardinality of a relationship kind.
+func relationshipCardinality(kind string) string {
+	switch kind {
+	case RelationshipReference, RelationshipEmbedding:
+		return CardinalityOne
+	default:
+		return CardinalityMany
+	}
+}
@@ -383,3 +383,53 @@ func TestGetTypeName(t *testing.T) {
 		})
 	}
 }
+
+func TestSortedFields(t *testing.T) {
+	type Sortable struct {
+		Zeta   string `json:"a_zeta"`
+		Alpha  string `json:"z_alpha"`
+		Hidden string `json:"-"`
+		Mid    string
+	}
+
+	metadata := Inspect[Sortable]()
+	metadata.Fields = append(metadata.Fields, FieldMetadata{Name: "Computed", JSONName: "computed", Virtual: true})
+
+	names := func(fields []FieldMetadata) []string {
+		result := make([]string, len(fields))
+		for i, field := range fields {
+			result[i] = field.Name
+		}
+		return result
+	}
+
+	tests := []struct {
+		by       FieldSort
+		expected []string
+	}{
+		{ByIndex, []string{"Zeta", "Alpha", "Hidden", "Mid", "Computed"}},
+		{ByName, []string{"Alpha", "Computed", "Hidden", "Mid", "Zeta"}},
+		{ByJSONName, []string{"Mid", "Zeta", "Computed", "Alpha", "Hidden"}},
+		{"unknown", []string{"Zeta", "Alpha", "Hidden", "Mid", "Computed"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.by), func(t *testing.T) {
+			if got := names(metadata.SortedFields(tt.by)); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("cache is not reordered", func(t *testing.T) {
+		sorted := metadata.SortedFields(ByName)
+		sorted[0].Name = "Mutated"
+
+		cached, _ := instance.cache.Get(metadata.FQDN)
+		if got := names(cached.Fields); !reflect.DeepEqual(got, []string{"Zeta", "Alpha", "Hidden", "Mid"}) {
+			t.Errorf("expected cached fields in declaration order, got %v", got)
+		}
+		if metadata.Fields[1].Name != "Alpha" {
+			t.Errorf("expected metadata fields to be untouched, got %v", names(metadata.Fields))
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package sentinel
+
+import (
+	"sort"
+	"strings"
+)
+
+// develVersion is the version build info reports for modules built from source.
+const develVersion = "(devel)"
+
+// SetModulePaths registers additional module roots whose packages Scan treats
+// as in-domain, replacing any roots registered earlier. Use it when models live
+// in several modules of one process, such as a Go workspace, so that Scan can
+// follow types rooted in any of them:
+//
+//	sentinel.SetModulePaths("github.com/acme/billing", "github.com/acme/identity")
+//
+// The main module from build info is always in-domain, as are dependencies
+// built from local source, which covers workspace modules and modules replaced
+// with a directory. Registered roots are added to these rather than replacing
+// them. A package matches a root when it is the root or below it.
+//
+// Relationships to types in registered roots are recorded from any package, so
+// those types appear in Relationships, the graph and the generators. The main
+// module and locally built dependencies only widen how far Scan recurses:
+// relationships within them are still only recorded between types of the same
+// package. Only affects types extracted after the call.
+func SetModulePaths(paths ...string) {
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path = strings.TrimSuffix(path, "/"); path != "" {
+			roots = append(roots, path)
+		}
+	}
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.modulePaths = roots
+}
+
+// ModulePaths returns every module root Scan treats as in-domain: the main
+// module, locally built dependencies and registered roots, sorted and without
+// duplicates.
+func ModulePaths() []string {
+	return instance.moduleRoots()
+}
+
+// moduleRoots returns the sorted, deduplicated module roots in the domain.
+func (s *Sentinel) moduleRoots() []string {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	roots := make([]string, 0, 1+len(s.localModules)+len(s.modulePaths))
+	if s.modulePath != "" {
+		roots = append(roots, s.modulePath)
+	}
+	roots = append(roots, s.localModules...)
+	roots = append(roots, s.modulePaths...)
+
+	sort.Strings(roots)
+	unique := roots[:0]
+	for i, root := range roots {
+		if i == 0 || root != roots[i-1] {
+			unique = append(unique, root)
+		}
+	}
+	return unique
+}
+
+// detectLocalModules returns the dependencies in build info that were built
+// from local source: workspace modules, reported with version "(devel)", and
+// modules replaced with a directory. Like detectModulePath it never panics.
+func detectLocalModules() (paths []string) {
+	defer func() {
+		if recover() != nil {
+			paths = nil
+		}
+	}()
+
+	info, ok := readBuildInfo()
+	if !ok || info == nil {
+		return nil
+	}
+
+	for _, dep := range info.Deps {
+		if dep == nil {
+			continue
+		}
+		local := dep.Version == develVersion
+		if dep.Replace != nil && dep.Replace.Version == "" {
+			local = true
+		}
+		if local {
+			paths = append(paths, dep.Path)
+		}
+	}
+	return paths
+}
+
+// withinModule reports whether pkg is the module root or one of its packages.
+// External test packages (pkg_test) belong to the module of the package they test.
+func withinModule(pkg, root string) bool {
+	pkg = strings.TrimSuffix(pkg, "_test")
+	return pkg == root || strings.HasPrefix(pkg, root+"/")
+}
@@ -0,0 +1,178 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"net/url"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"testing"
+)
+
+func TestModuleRoots(t *testing.T) {
+	t.Run("registered roots are in domain", func(t *testing.T) {
+		s := &Sentinel{
+			modulePath:  "github.com/acme/app",
+			modulePaths: []string{"github.com/acme/billing", "github.com/acme/identity"},
+		}
+
+		for _, pkg := range []string{
+			"github.com/acme/app/models",
+			"github.com/acme/billing",
+			"github.com/acme/billing/invoices",
+			"github.com/acme/identity/users",
+		} {
+			if !s.isInModuleDomain(pkg) {
+				t.Errorf("expected %s to be in domain", pkg)
+			}
+		}
+		if s.isInModuleDomain("github.com/acme/shipping") {
+			t.Error("expected unregistered module to be out of domain")
+		}
+	})
+
+	t.Run("local modules are in domain", func(t *testing.T) {
+		s := &Sentinel{localModules: []string{"github.com/acme/shared"}}
+
+		if !s.isInModuleDomain("github.com/acme/shared/types") {
+			t.Error("expected local module package to be in domain")
+		}
+	})
+
+	t.Run("roots match on path boundaries", func(t *testing.T) {
+		s := &Sentinel{modulePath: "github.com/acme/app", modulePaths: []string{"github.com/acme/billing"}}
+
+		if s.isInModuleDomain("github.com/acme/application") {
+			t.Error("expected sibling module sharing a prefix to be out of domain")
+		}
+		if s.isInModuleDomain("github.com/acme/billingv2/models") {
+			t.Error("expected sibling of a registered root to be out of domain")
+		}
+		if !s.isInModuleDomain("github.com/acme/app_test") || !s.isInModuleDomain("github.com/acme/app/models_test") {
+			t.Error("expected external test packages to belong to their module")
+		}
+	})
+
+	t.Run("module paths are merged and sorted", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		original := instance.localModules
+		defer func() { instance.localModules = original }()
+		instance.localModules = []string{"github.com/acme/shared"}
+
+		SetModulePaths("github.com/acme/billing/", "", "github.com/acme/shared")
+
+		expected := []string{"github.com/acme/billing", "github.com/acme/shared"}
+		if instance.modulePath != "" {
+			expected = append(expected, instance.modulePath)
+		}
+		sort.Strings(expected)
+
+		if got := ModulePaths(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestDetectLocalModules(t *testing.T) {
+	original := readBuildInfo
+	defer func() { readBuildInfo = original }()
+
+	t.Run("workspace and replaced modules", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{
+				Main: debug.Module{Path: "github.com/acme/app"},
+				Deps: []*debug.Module{
+					{Path: "github.com/acme/billing", Version: "(devel)"},
+					{Path: "github.com/acme/identity", Version: "v1.2.0", Replace: &debug.Module{Path: "../identity"}},
+					{Path: "github.com/acme/forked", Version: "v1.0.0", Replace: &debug.Module{Path: "github.com/fork/forked", Version: "v1.0.1"}},
+					{Path: "golang.org/x/text", Version: "v0.14.0"},
+					nil,
+				},
+			}, true
+		}
+
+		got := detectLocalModules()
+		expected := []string{"github.com/acme/billing", "github.com/acme/identity"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("build info unavailable", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+		if got := detectLocalModules(); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	})
+
+	t.Run("build info panics", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { panic("unsupported") }
+		if got := detectLocalModules(); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	})
+}
+
+func TestScanWithRegisteredModule(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	original := instance.modulePath
+	defer func() { instance.modulePath = original }()
+
+	// Simulate models living outside the main module
+	instance.modulePath = "github.com/acme/app"
+	profileFQDN := getFQDN(reflect.TypeOf(Profile{}))
+
+	Scan[User]()
+	if _, ok := Lookup(profileFQDN); ok {
+		t.Fatal("expected Scan to stay on the root type outside the domain")
+	}
+
+	Reset()
+	SetModulePaths(reflect.TypeOf(User{}).PkgPath())
+
+	Scan[User]()
+	if _, ok := Lookup(profileFQDN); !ok {
+		t.Error("expected Scan to follow relationships in a registered module")
+	}
+}
+
+func TestRelationshipsToRegisteredModule(t *testing.T) {
+	s := &Sentinel{
+		modulePath:  "github.com/acme/app",
+		modulePaths: []string{"github.com/acme/billing"},
+	}
+	source := "github.com/acme/app/models"
+	if !s.isInPackageDomain("github.com/acme/billing/invoices", source) {
+		t.Error("expected a registered module to be in the relationship domain")
+	}
+	if s.isInPackageDomain("github.com/acme/app/orders", source) {
+		t.Error("expected other packages of the main module to stay out of the relationship domain")
+	}
+
+	Reset()
+	defer Reset()
+
+	type Bookmark struct {
+		Target *url.URL `json:"target"`
+	}
+
+	if rels := Inspect[Bookmark]().Relationships; len(rels) != 0 {
+		t.Fatalf("expected no relationship outside the package, got %+v", rels)
+	}
+
+	Reset()
+	SetModulePaths("net/url")
+
+	metadata := Scan[Bookmark]()
+	if len(metadata.Relationships) != 1 || metadata.Relationships[0].To != "net/url.URL" {
+		t.Fatalf("expected a relationship to the registered module, got %+v", metadata.Relationships)
+	}
+	if _, ok := Lookup("net/url.URL"); !ok {
+		t.Error("expected Scan to follow the relationship into the registered module")
+	}
+}
@@ -0,0 +1,80 @@
+package sentinel
+
+import "reflect"
+
+// uuidBytes is the underlying type of nullable UUID wrappers such as
+// pgtype.UUID, which marshal their bytes as a UUID string.
+const uuidBytes = "[16]uint8"
+
+// builtinNullableTypes maps well-known nullable wrapper structs, by FQDN, to the
+// type of the value they wrap.
+var builtinNullableTypes = map[string]string{
+	"database/sql.NullBool":    "bool",
+	"database/sql.NullByte":    "uint8",
+	"database/sql.NullFloat64": "float64",
+	"database/sql.NullInt16":   "int16",
+	"database/sql.NullInt32":   "int32",
+	"database/sql.NullInt64":   "int64",
+	"database/sql.NullString":  "string",
+	"database/sql.NullTime":    "time.Time",
+
+	"github.com/jackc/pgx/v5/pgtype.Bool":        "bool",
+	"github.com/jackc/pgx/v5/pgtype.Date":        "time.Time",
+	"github.com/jackc/pgx/v5/pgtype.Float4":      "float32",
+	"github.com/jackc/pgx/v5/pgtype.Float8":      "float64",
+	"github.com/jackc/pgx/v5/pgtype.Int2":        "int16",
+	"github.com/jackc/pgx/v5/pgtype.Int4":        "int32",
+	"github.com/jackc/pgx/v5/pgtype.Int8":        "int64",
+	"github.com/jackc/pgx/v5/pgtype.Text":        "string",
+	"github.com/jackc/pgx/v5/pgtype.Timestamp":   "time.Time",
+	"github.com/jackc/pgx/v5/pgtype.Timestamptz": "time.Time",
+	"github.com/jackc/pgx/v5/pgtype.UUID":        uuidBytes,
+}
+
+// RegisterNullableType declares t as a nullable wrapper around a scalar value
+// of type underlying (e.g. "string"). Fields of type t are reported as
+// KindScalar with Nullable set and Underlying holding underlying, rather than
+// as structs, and never form relationships. The database/sql Null types
+// (including the generic sql.Null[T]) and the common pgx v5 pgtype types are
+// recognized without registration; registering one of them overrides it.
+// Pointer types register their element type. Only affects types extracted
+// after the call.
+func RegisterNullableType(t reflect.Type, underlying string) {
+	fqdn := getFQDN(t)
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if instance.nullableTypes == nil {
+		instance.nullableTypes = make(map[string]string)
+	}
+	instance.nullableTypes[fqdn] = underlying
+}
+
+// nullableUnderlying returns the wrapped type of a nullable wrapper struct.
+// The bool is false if t is not a known nullable type.
+func (s *Sentinel) nullableUnderlying(t reflect.Type) (string, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", false
+	}
+	fqdn := getFQDN(t)
+
+	s.configMutex.RLock()
+	underlying, ok := s.nullableTypes[fqdn]
+	s.configMutex.RUnlock()
+	if ok {
+		return underlying, true
+	}
+
+	if underlying, ok := builtinNullableTypes[fqdn]; ok {
+		return underlying, true
+	}
+
+	// The generic sql.Null[T] wraps its type argument
+	if t.PkgPath() == "database/sql" {
+		if base, args := parseGenericName(t.Name()); base == "Null" && len(args) == 1 {
+			return args[0], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,125 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+type NullableMoney struct {
+	Cents int64
+	Valid bool
+}
+
+type NullableRecord struct {
+	Name      sql.NullString     `json:"name"`
+	Count     sql.NullInt64      `json:"count"`
+	Seen      sql.NullTime       `json:"seen"`
+	Score     sql.Null[float32]  `json:"score"`
+	Amount    NullableMoney      `json:"amount"`
+	Pointer   *sql.NullString    `json:"pointer"`
+	Reference NullableReferenced `json:"reference"`
+}
+
+// NullableUUID mirrors pgtype.UUID, which marshals as a UUID string or null.
+type NullableUUID struct {
+	Bytes [16]byte
+	Valid bool
+}
+
+func (u NullableUUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(`"00000000-0000-0000-0000-000000000000"`), nil
+}
+
+type NullableReferenced struct {
+	ID string
+}
+
+func nullableFields(fields []FieldMetadata) map[string]FieldMetadata {
+	byName := make(map[string]FieldMetadata, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	return byName
+}
+
+func TestNullableTypes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("database/sql wrappers are nullable scalars", func(t *testing.T) {
+		fields := nullableFields(Inspect[NullableRecord]().Fields)
+
+		expected := map[string]string{
+			"Name":  "string",
+			"Count": "int64",
+			"Seen":  "time.Time",
+			"Score": "float32",
+		}
+		for name, underlying := range expected {
+			field := fields[name]
+			if field.Kind != KindScalar || !field.Nullable || field.Underlying != underlying {
+				t.Errorf("field %s: expected nullable scalar %s, got kind=%s nullable=%v underlying=%q",
+					name, underlying, field.Kind, field.Nullable, field.Underlying)
+			}
+		}
+	})
+
+	t.Run("other structs are unaffected", func(t *testing.T) {
+		fields := nullableFields(Inspect[NullableRecord]().Fields)
+
+		for _, name := range []string{"Amount", "Reference"} {
+			if field := fields[name]; field.Kind != KindStruct || field.Nullable {
+				t.Errorf("field %s: expected plain struct, got kind=%s nullable=%v", name, field.Kind, field.Nullable)
+			}
+		}
+		if field := fields["Pointer"]; field.Kind != KindPointer || field.Underlying != "" {
+			t.Errorf("expected pointer to stay a pointer, got kind=%s underlying=%q", field.Kind, field.Underlying)
+		}
+	})
+
+	Reset()
+	RegisterNullableType(reflect.TypeOf(&NullableMoney{}), "int64")
+
+	t.Run("registered wrapper", func(t *testing.T) {
+		metadata := Inspect[NullableRecord]()
+
+		field := nullableFields(metadata.Fields)["Amount"]
+		if field.Kind != KindScalar || !field.Nullable || field.Underlying != "int64" {
+			t.Errorf("expected registered nullable int64, got %+v", field)
+		}
+
+		for _, rel := range metadata.Relationships {
+			if rel.Field == "Amount" {
+				t.Errorf("expected no relationship for a nullable wrapper, got %+v", rel)
+			}
+		}
+		if len(metadata.Relationships) != 1 || metadata.Relationships[0].Field != "Reference" {
+			t.Errorf("expected only the Reference relationship, got %+v", metadata.Relationships)
+		}
+	})
+
+	t.Run("uuid wrappers are nullable uuid strings", func(t *testing.T) {
+		// Registered with the same underlying type as the built-in pgtype.UUID
+		RegisterNullableType(reflect.TypeOf(NullableUUID{}), builtinNullableTypes["github.com/jackc/pgx/v5/pgtype.UUID"])
+
+		g := &jsonSchemaGenerator{}
+		schema := g.valueSchema(reflect.TypeOf(NullableUUID{}))
+		expected := map[string]any{"type": []string{"string", "null"}, "format": "uuid"}
+		if !reflect.DeepEqual(schema, expected) {
+			t.Errorf("expected %v, got %v", expected, schema)
+		}
+	})
+
+	t.Run("compact export marks wrappers nullable", func(t *testing.T) {
+		field := nullableFields(Inspect[NullableRecord]().Fields)["Name"]
+		if !isNullableField(field) {
+			t.Error("expected sql.NullString to be nullable")
+		}
+	})
+}
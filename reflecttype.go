@@ -0,0 +1,229 @@
+package sentinel
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// anyType is the reflect.Type of interface{}.
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// scalarTypes maps predeclared type names to their reflect types.
+var scalarTypes = map[string]reflect.Type{
+	"bool":       reflect.TypeOf(false),
+	"string":     reflect.TypeOf(""),
+	"int":        reflect.TypeOf(int(0)),
+	"int8":       reflect.TypeOf(int8(0)),
+	"int16":      reflect.TypeOf(int16(0)),
+	"int32":      reflect.TypeOf(int32(0)),
+	"int64":      reflect.TypeOf(int64(0)),
+	"uint":       reflect.TypeOf(uint(0)),
+	"uint8":      reflect.TypeOf(uint8(0)),
+	"uint16":     reflect.TypeOf(uint16(0)),
+	"uint32":     reflect.TypeOf(uint32(0)),
+	"uint64":     reflect.TypeOf(uint64(0)),
+	"uintptr":    reflect.TypeOf(uintptr(0)),
+	"float32":    reflect.TypeOf(float32(0)),
+	"float64":    reflect.TypeOf(float64(0)),
+	"complex64":  reflect.TypeOf(complex64(0)),
+	"complex128": reflect.TypeOf(complex128(0)),
+	"byte":       reflect.TypeOf(byte(0)),
+	"rune":       reflect.TypeOf(rune(0)),
+}
+
+// BuildReflectType reconstructs a struct type from the metadata's Fields with
+// reflect.StructOf. It is intended for metadata without a ReflectType, such as
+// metadata read back with ParseSchemaDocument.
+//
+// Fields keep their names and tags. Virtual fields and fields promoted by
+// SetFlattenEmbedded are skipped. Fields that still carry a ReflectType use it
+// directly; otherwise the type is rebuilt from FieldMetadata.Type. Scalars,
+// pointers, slices, arrays and maps are supported, interface and func fields
+// become interface{}, and named struct types are resolved through the field's
+// relationship to another cached type, recursively. Recursive references also
+// become interface{} since reflect cannot construct recursive types.
+//
+// Embedded structs stay embedded, so encoding/json still promotes their fields,
+// unless their type has methods: reflect.StructOf cannot embed those, so they
+// become regular fields named after the type.
+//
+// The result is structurally equivalent to the original type but not identical:
+// it is unnamed and has no methods. Named types that are neither predeclared
+// nor reachable through a relationship (such as time.Time or defined scalar
+// types) can only be rebuilt while the field still carries its ReflectType.
+func (m Metadata) BuildReflectType() (reflect.Type, error) {
+	builder := &typeBuilder{building: make(map[string]bool)}
+	t, err := builder.build(m)
+	if err != nil {
+		return nil, fmt.Errorf("sentinel: build reflect type for %s: %w", m.FQDN, err)
+	}
+	return t, nil
+}
+
+// typeBuilder tracks the types under construction to break recursive references.
+type typeBuilder struct {
+	building map[string]bool
+}
+
+// build reconstructs the struct type for a metadata entry.
+func (b *typeBuilder) build(m Metadata) (t reflect.Type, err error) {
+	b.building[m.FQDN] = true
+	defer delete(b.building, m.FQDN)
+
+	declared := declaredFields(m.Fields)
+	fields := make([]reflect.StructField, 0, len(declared))
+	for _, field := range declared {
+		// Promoted fields are rebuilt through their embedded field, and
+		// StructOf cannot create unexported fields
+		if len(field.Index) > 1 || !token.IsExported(field.Name) {
+			continue
+		}
+		ft, err := b.fieldType(m, field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fields = append(fields, reflect.StructField{
+			Name:      field.Name,
+			Type:      ft,
+			Tag:       buildStructTag(field.Tags),
+			Anonymous: field.Anonymous && embeddable(ft),
+		})
+	}
+
+	// StructOf panics on invalid fields rather than returning an error
+	defer func() {
+		if r := recover(); r != nil {
+			t, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return reflect.StructOf(fields), nil
+}
+
+// embeddable reports whether StructOf can embed t: a struct or pointer to
+// struct without methods, since StructOf panics on promoting methods.
+func embeddable(t reflect.Type) bool {
+	if !isStructOrStructPointer(t) || t.NumMethod() > 0 {
+		return false
+	}
+	return t.Kind() == reflect.Ptr || reflect.PointerTo(t).NumMethod() == 0
+}
+
+// fieldType returns the reflect type of a field, rebuilding it from its type string when needed.
+func (b *typeBuilder) fieldType(m Metadata, field FieldMetadata) (reflect.Type, error) {
+	if field.ReflectType != nil {
+		return field.ReflectType, nil
+	}
+
+	resolve := func(name string) (reflect.Type, error) {
+		for _, rel := range m.Relationships {
+			if rel.Field != field.Name {
+				continue
+			}
+			if b.building[rel.To] {
+				return anyType, nil
+			}
+			target, ok := instance.cache.Get(rel.To)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNotCached, rel.To)
+			}
+			return b.build(target)
+		}
+		return nil, fmt.Errorf("unresolved type %q", name)
+	}
+
+	return parseTypeString(field.Type, resolve)
+}
+
+// parseTypeString builds a reflect type from its string form, as produced by
+// reflect.Type.String. Named types are passed to resolve.
+func parseTypeString(s string, resolve func(name string) (reflect.Type, error)) (reflect.Type, error) {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		elem, err := parseTypeString(s[1:], resolve)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.PointerTo(elem), nil
+
+	case strings.HasPrefix(s, "[]"):
+		elem, err := parseTypeString(s[2:], resolve)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+
+	case strings.HasPrefix(s, "["):
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("malformed array type %q", s)
+		}
+		length, err := strconv.Atoi(s[1:end])
+		if err != nil {
+			return nil, fmt.Errorf("malformed array type %q", s)
+		}
+		elem, err := parseTypeString(s[end+1:], resolve)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ArrayOf(length, elem), nil
+
+	case strings.HasPrefix(s, "map["):
+		end := matchingBracket(s, len("map"))
+		if end < 0 {
+			return nil, fmt.Errorf("malformed map type %q", s)
+		}
+		key, err := parseTypeString(s[len("map["):end], resolve)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := parseTypeString(s[end+1:], resolve)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(key, elem), nil
+
+	case s == "any", strings.HasPrefix(s, "interface"), strings.HasPrefix(s, "func"):
+		return anyType, nil
+	}
+
+	if scalar, ok := scalarTypes[s]; ok {
+		return scalar, nil
+	}
+	return resolve(s)
+}
+
+// matchingBracket returns the index of the ']' closing the '[' at open, or -1.
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// buildStructTag renders a tag map as a conventional struct tag with sorted keys.
+func buildStructTag(tags map[string]string) reflect.StructTag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+":"+strconv.Quote(tags[key]))
+	}
+	return reflect.StructTag(strings.Join(parts, " "))
+}
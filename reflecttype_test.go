@@ -0,0 +1,185 @@
+package sentinel
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stripReflectTypes returns a copy of metadata without reflect types, as parsed from a schema document.
+func stripReflectTypes(m Metadata) Metadata {
+	m.ReflectType = nil
+	fields := make([]FieldMetadata, len(m.Fields))
+	copy(fields, m.Fields)
+	for i := range fields {
+		fields[i].ReflectType = nil
+	}
+	m.Fields = fields
+	return m
+}
+
+func TestBuildReflectType(t *testing.T) {
+	t.Run("simple struct", func(t *testing.T) {
+		type Simple struct {
+			Name   string            `json:"name" validate:"required"`
+			Count  int               `json:"count"`
+			Scores []float64         `json:"scores"`
+			Grid   [3]int            `json:"grid"`
+			Labels map[string]string `json:"labels"`
+			Ptr    *bool             `json:"ptr"`
+			Any    interface{}       `json:"any"`
+		}
+
+		original := reflect.TypeOf(Simple{})
+		s := &Sentinel{registeredTags: make(map[string]bool)}
+		metadata := stripReflectTypes(s.extractMetadata(original))
+
+		rebuilt, err := metadata.BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rebuilt.NumField() != original.NumField() {
+			t.Fatalf("expected %d fields, got %d", original.NumField(), rebuilt.NumField())
+		}
+		for i := 0; i < original.NumField(); i++ {
+			want, got := original.Field(i), rebuilt.Field(i)
+			if want.Name != got.Name {
+				t.Errorf("field %d: expected name %s, got %s", i, want.Name, got.Name)
+			}
+			if want.Type != got.Type {
+				t.Errorf("field %s: expected type %s, got %s", want.Name, want.Type, got.Type)
+			}
+			if want.Tag.Get("json") != got.Tag.Get("json") {
+				t.Errorf("field %s: expected json tag %q, got %q", want.Name, want.Tag.Get("json"), got.Tag.Get("json"))
+			}
+		}
+		if rebuilt.Field(0).Tag.Get("validate") != "required" {
+			t.Errorf("expected validate tag to survive, got %q", rebuilt.Field(0).Tag)
+		}
+		if !rebuilt.ConvertibleTo(original) {
+			t.Error("expected rebuilt type to be convertible to the original")
+		}
+	})
+
+	t.Run("resolves cached struct references", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := stripReflectTypes(Scan[Profile]())
+
+		rebuilt, err := metadata.BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		address, ok := rebuilt.FieldByName("Address")
+		if !ok {
+			t.Fatal("expected Address field")
+		}
+		if address.Type.Kind() != reflect.Ptr || address.Type.Elem().Kind() != reflect.Struct {
+			t.Fatalf("expected pointer to struct, got %s", address.Type)
+		}
+		if _, ok := address.Type.Elem().FieldByName("Street"); !ok {
+			t.Error("expected referenced struct to carry Street field")
+		}
+	})
+
+	t.Run("unresolved named type", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := stripReflectTypes(Inspect[Profile]())
+
+		_, err := metadata.BuildReflectType()
+		if !errors.Is(err, ErrNotCached) {
+			t.Errorf("expected ErrNotCached for uncached reference, got %v", err)
+		}
+	})
+
+	t.Run("embedded structs stay embedded", func(t *testing.T) {
+		type EmbeddedBase struct {
+			Version int `json:"version"`
+		}
+		type EmbeddingDoc struct {
+			EmbeddedBase
+			time.Time
+			Title string `json:"title"`
+		}
+
+		instance.cache.Clear()
+		live := Scan[EmbeddingDoc]()
+		for name, metadata := range map[string]Metadata{"live": live, "parsed": stripReflectTypes(live)} {
+			if name == "parsed" {
+				// time.Time can only be rebuilt from its ReflectType
+				metadata.Fields = slices.DeleteFunc(metadata.Fields, func(f FieldMetadata) bool { return f.Name == "Time" })
+			}
+			rebuilt, err := metadata.BuildReflectType()
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+			if !rebuilt.Field(0).Anonymous {
+				t.Errorf("%s: expected EmbeddedBase to stay embedded", name)
+			}
+			data, err := json.Marshal(reflect.New(rebuilt).Elem().Interface())
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+			if !strings.Contains(string(data), `{"version":0,`) {
+				t.Errorf("%s: expected promoted version key, got %s", name, data)
+			}
+		}
+
+		// Types with methods cannot be embedded by reflect.StructOf
+		rebuilt, _ := live.BuildReflectType()
+		if field, _ := rebuilt.FieldByName("Time"); field.Anonymous {
+			t.Error("expected time.Time to become a regular field")
+		}
+	})
+
+	t.Run("live metadata", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := Inspect[User]()
+
+		rebuilt, err := metadata.BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		field, _ := rebuilt.FieldByName("Profile")
+		if field.Type != reflect.TypeOf(&Profile{}) {
+			t.Errorf("expected original field type to be reused, got %s", field.Type)
+		}
+	})
+}
+
+func TestParseTypeString(t *testing.T) {
+	unresolved := func(name string) (reflect.Type, error) {
+		return nil, errors.New("unresolved " + name)
+	}
+
+	tests := map[string]reflect.Type{
+		"string":                     reflect.TypeOf(""),
+		"*int64":                     reflect.TypeOf(new(int64)),
+		"[]uint8":                    reflect.TypeOf([]byte{}),
+		"[4]float32":                 reflect.TypeOf([4]float32{}),
+		"map[string][]int":           reflect.TypeOf(map[string][]int{}),
+		"map[[2]int]map[string]bool": reflect.TypeOf(map[[2]int]map[string]bool{}),
+		"interface {}":               anyType,
+		"func(string) error":         anyType,
+	}
+
+	for input, expected := range tests {
+		got, err := parseTypeString(input, unresolved)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", input, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, got)
+		}
+	}
+
+	if _, err := parseTypeString("time.Time", unresolved); err == nil {
+		t.Error("expected error for unresolved named type")
+	}
+}
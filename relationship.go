@@ -1,6 +1,7 @@
 package sentinel
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
@@ -21,23 +22,22 @@ func GetReferencedBy[T any]() []TypeRelationship {
 	var references []TypeRelationship
 
 	// Search through all cached types
-	for _, fqdn := range instance.cache.Keys() {
-		if metadata, found := instance.cache.Get(fqdn); found {
-			// Check each relationship in this type
-			for _, rel := range metadata.Relationships {
-				if rel.To == targetFQDN {
-					references = append(references, rel)
-				}
+	instance.cache.ForEach(func(_ string, metadata Metadata) bool {
+		// Check each relationship in this type
+		for _, rel := range metadata.Relationships {
+			if rel.To == targetFQDN {
+				references = append(references, rel)
 			}
 		}
-	}
+		return true
+	})
 
 	return references
 }
 
 // extractRelationships discovers relationships to other types within the same package domain.
 // If visited is non-nil, it will recursively scan related types in the same module.
-func (s *Sentinel) extractRelationships(t reflect.Type, visited map[string]bool) []TypeRelationship {
+func (s *Sentinel) extractRelationships(t reflect.Type, visited *visitedSet, depth int) []TypeRelationship {
 	var relationships []TypeRelationship
 
 	if t.Kind() == reflect.Ptr {
@@ -62,41 +62,146 @@ func (s *Sentinel) extractRelationships(t reflect.Type, visited map[string]bool)
 		rel := s.extractRelationship(field, rootPackage)
 		if rel != nil {
 			rel.From = getFQDN(t)
+			if rel.Kind == RelationshipReference {
+				rel.Mutual = referencesBack(s.getStructTypeFromField(field.Type), t)
+			}
 			relationships = append(relationships, *rel)
 
-			// If visited map is provided (Scan mode), recursively scan related types
+			// If visited set is provided (Scan mode), recursively scan related types
 			if visited != nil && s.isInModuleDomain(rel.ToPackage) {
 				// Extract the underlying struct type from the field
 				relType := s.getStructTypeFromField(field.Type)
 				if relType != nil {
-					s.extractMetadataInternal(relType, visited)
+					// Abort this branch once the depth limit is reached
+					if limit := s.depthLimit(); depth >= limit {
+						visited.Warn(depthExceededWarning(limit, t, field.Name, relType))
+						continue
+					}
+					s.extractMetadataInternal(relType, visited, depth+1)
 				}
 			}
 		}
 	}
 
+	// Scan the struct type arguments of generic instantiations
+	if visited != nil {
+		s.scanTypeArguments(t, visited, depth)
+	}
+
 	return relationships
 }
 
+// scanTypeArguments scans the struct type arguments of an instantiated generic
+// type that are in the module domain, such as Order for Page[Order]. They are
+// scanned even when no relationship is recorded for them, for example because
+// the generic type lives in another package.
+func (s *Sentinel) scanTypeArguments(t reflect.Type, visited *visitedSet, depth int) {
+	_, args := parseGenericName(t.Name())
+	if len(args) == 0 {
+		return
+	}
+
+	for i, arg := range typeArgumentStructs(t, args) {
+		if arg == nil || !s.isInModuleDomain(arg.PkgPath()) {
+			continue
+		}
+		if _, ok := s.nullableUnderlying(arg); ok {
+			continue
+		}
+		if _, ok := s.scalarFormat(arg); ok {
+			continue
+		}
+		if limit := s.depthLimit(); depth >= limit {
+			visited.Warn(depthExceededWarning(limit, t, fmt.Sprintf("type argument %d", i+1), arg))
+			continue
+		}
+		s.extractMetadataInternal(arg, visited, depth+1)
+	}
+}
+
+// typeArgumentStructs resolves the type arguments of a generic struct to the
+// struct types they name, by finding them among the types its fields use.
+// Fields tagged `sentinel:"-"` are not searched. Each entry is nil when the
+// argument is not a struct or does not appear in any field, as for a phantom
+// type parameter.
+func typeArgumentStructs(t reflect.Type, args []string) []reflect.Type {
+	resolved := make([]reflect.Type, len(args))
+	wanted := make(map[string]int, len(args))
+	for i, arg := range args {
+		wanted[arg] = i
+	}
+
+	seen := map[reflect.Type]bool{t: true}
+	var walk, walkFields func(ft reflect.Type)
+	walk = func(ft reflect.Type) {
+		if seen[ft] {
+			return
+		}
+		seen[ft] = true
+
+		switch ft.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+			walk(ft.Elem())
+		case reflect.Map:
+			walk(ft.Key())
+			walk(ft.Elem())
+		case reflect.Struct:
+			if i, ok := wanted[getFQDN(ft)]; ok && ft.Name() != "" {
+				resolved[i] = ft
+			}
+			walkFields(ft)
+		}
+	}
+	walkFields = func(st reflect.Type) {
+		for j := 0; j < st.NumField(); j++ {
+			if field := st.Field(j); field.Tag.Get("sentinel") != "-" {
+				walk(field.Type)
+			}
+		}
+	}
+	walkFields(t)
+	return resolved
+}
+
 // extractRelationship checks if a field represents a relationship to another struct type.
+// Fields tagged `sentinel:"-"` are excluded from relationship detection.
 func (s *Sentinel) extractRelationship(field reflect.StructField, rootPackage string) *TypeRelationship {
+	if field.Tag.Get("sentinel") == "-" {
+		return nil
+	}
+
 	ft := field.Type
 
+	var rel *TypeRelationship
+
 	// Handle different field types
 	switch ft.Kind() {
 	case reflect.Struct:
+		// Nullable scalar wrappers are values, not related types
+		if _, ok := s.nullableUnderlying(ft); ok {
+			return nil
+		}
 		// Direct struct embedding
 		if field.Anonymous {
-			return s.createRelationshipIfInDomain(field, ft, RelationshipEmbedding, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, ft, RelationshipEmbedding, rootPackage)
+			// Record the instantiation of embedded generic bases
+			if rel != nil {
+				rel.EmbeddedInline = isInlineEmbedding(field)
+				if base, args := parseGenericName(ft.Name()); len(args) > 0 {
+					rel.GenericBase = base
+					rel.TypeArguments = args
+				}
+			}
+			break
 		}
 		// Regular struct field
-		return s.createRelationshipIfInDomain(field, ft, RelationshipReference, rootPackage)
+		rel = s.createRelationshipIfInDomain(field, ft, RelationshipReference, rootPackage)
 
 	case reflect.Ptr:
 		// Pointer to struct
 		elem := ft.Elem()
 		if elem.Kind() == reflect.Struct {
-			return s.createRelationshipIfInDomain(field, elem, RelationshipReference, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, elem, RelationshipReference, rootPackage)
 		}
 
 	case reflect.Slice, reflect.Array:
@@ -104,9 +209,9 @@ func (s *Sentinel) extractRelationship(field reflect.StructField, rootPackage st
 		elem := ft.Elem()
 		// Handle []T and []*T
 		if elem.Kind() == reflect.Struct {
-			return s.createRelationshipIfInDomain(field, elem, RelationshipCollection, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, elem, RelationshipCollection, rootPackage)
 		} else if elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct {
-			return s.createRelationshipIfInDomain(field, elem.Elem(), RelationshipCollection, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, elem.Elem(), RelationshipCollection, rootPackage)
 		}
 
 	case reflect.Map:
@@ -114,13 +219,60 @@ func (s *Sentinel) extractRelationship(field reflect.StructField, rootPackage st
 		val := ft.Elem()
 		// Handle map[K]V and map[K]*V where V is struct
 		if val.Kind() == reflect.Struct {
-			return s.createRelationshipIfInDomain(field, val, RelationshipMap, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, val, RelationshipMap, rootPackage)
 		} else if val.Kind() == reflect.Ptr && val.Elem().Kind() == reflect.Struct {
-			return s.createRelationshipIfInDomain(field, val.Elem(), RelationshipMap, rootPackage)
+			rel = s.createRelationshipIfInDomain(field, val.Elem(), RelationshipMap, rootPackage)
 		}
 	}
 
-	return nil
+	if rel != nil {
+		rel.ViaPointer = isPointerRelationship(ft)
+	}
+
+	return rel
+}
+
+// referencesBack reports whether target has a reference field (T or *T, not
+// embedded) to source, making a reference from source to target mutual.
+// Self-references are never mutual.
+func referencesBack(target, source reflect.Type) bool {
+	if target == nil || target == source {
+		return false
+	}
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Field(i)
+		if !field.IsExported() || field.Anonymous || field.Tag.Get("sentinel") == "-" {
+			continue
+		}
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft == source {
+			return true
+		}
+	}
+	return false
+}
+
+// isInlineEmbedding reports whether encoding/json promotes an embedded field's
+// fields into the parent object, which happens unless the json tag names it.
+func isInlineEmbedding(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name == ""
+}
+
+// isPointerRelationship reports whether a relationship field reaches its target
+// through a pointer: *T, []*T, [N]*T or map[K]*T.
+func isPointerRelationship(ft reflect.Type) bool {
+	switch ft.Kind() {
+	case reflect.Ptr:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return ft.Elem().Kind() == reflect.Ptr
+	default:
+		return false
+	}
 }
 
 // createRelationshipIfInDomain creates a TypeRelationship if the target type is in the same package domain.
@@ -132,35 +284,71 @@ func (s *Sentinel) createRelationshipIfInDomain(field reflect.StructField, targe
 		return nil
 	}
 
+	// Registered scalar types are values, however they are held
+	if _, ok := s.scalarFormat(targetType); ok {
+		return nil
+	}
+
 	// Check if in same package domain
 	if !s.isInPackageDomain(targetPkg, rootPackage) {
 		return nil
 	}
 
 	return &TypeRelationship{
-		To:        getFQDN(targetType),
-		Field:     field.Name,
-		Kind:      kind,
-		ToPackage: targetPkg,
+		To:          getFQDN(targetType),
+		Field:       field.Name,
+		Kind:        kind,
+		Cardinality: relationshipCardinality(kind),
+		ToPackage:   targetPkg,
 	}
 }
 
-// isInPackageDomain checks if a target package is within the same domain as the source.
-// For Inspect: requires exact package match.
-// For Scan: checks module root match (first 3 path segments).
-func (*Sentinel) isInPackageDomain(targetPkg, sourcePkg string) bool {
-	// Only include exact same package to avoid noise from external dependencies
-	return targetPkg == sourcePkg
+// isInPackageDomain checks if relationships from the source package to the
+// target package are recorded: the packages must match, or the target must
+// belong to a root registered with SetModulePaths.
+func (s *Sentinel) isInPackageDomain(targetPkg, sourcePkg string) bool {
+	// Only include the same package to avoid noise from external dependencies
+	if targetPkg == sourcePkg {
+		return true
+	}
+
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	for _, root := range s.modulePaths {
+		if withinModule(targetPkg, root) {
+			return true
+		}
+	}
+	return false
 }
 
-// isInModuleDomain checks if a target package belongs to the same module.
-// Uses the module path from debug.ReadBuildInfo() for accurate detection.
-// Returns false if build info is unavailable (graceful degradation).
+// isInModuleDomain checks if a target package belongs to a module in the domain:
+// the main module from debug.ReadBuildInfo(), a locally built dependency, or a
+// root registered with SetModulePaths.
+// Returns false if no module root is known (graceful degradation).
 func (s *Sentinel) isInModuleDomain(targetPkg string) bool {
-	if targetPkg == "" || s.modulePath == "" {
+	if targetPkg == "" {
 		return false
 	}
-	return strings.HasPrefix(targetPkg, s.modulePath)
+	if s.modulePath != "" && withinModule(targetPkg, s.modulePath) {
+		return true
+	}
+
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	for _, root := range s.localModules {
+		if withinModule(targetPkg, root) {
+			return true
+		}
+	}
+	for _, root := range s.modulePaths {
+		if withinModule(targetPkg, root) {
+			return true
+		}
+	}
+	return false
 }
 
 // getStructTypeFromField extracts the underlying struct type from a field.
@@ -2,6 +2,7 @@ package sentinel
 
 import (
 	"reflect"
+	"runtime/debug"
 	"testing"
 )
 
@@ -47,7 +48,54 @@ type Data struct {
 	Value string `json:"value"`
 }
 
+// Generic types for embedding tests.
+type ResourceID string
+
+type Base[T any] struct {
+	ID T `json:"id"`
+}
+
+type Keyed[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+type Resource struct {
+	Base[ResourceID]
+	Name string `json:"name"`
+}
+
+type Entry struct {
+	Keyed[string, map[string]int]
+}
+
 // Types in different package (won't be included in relationships).
+// Generic instantiations.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type Cursor[T any] struct {
+	Next string
+	last *T //nolint:unused // only its type matters
+}
+
+type Pair[K comparable, V any] struct {
+	Values map[K][]*V
+}
+
+// Mutually referencing types for one-to-one detection.
+type Passport struct {
+	Holder *Citizen `json:"holder"`
+	Number string   `json:"number"`
+}
+
+type Citizen struct {
+	Passport *Passport `json:"passport"`
+	Name     string    `json:"name"`
+}
+
 type ExternalDB struct {
 	Connection string
 }
@@ -229,6 +277,23 @@ func TestRelationshipAPIs(t *testing.T) {
 		}
 	})
 
+	t.Run("GetReferencedBy matches full FQDNs", func(t *testing.T) {
+		// A same-named type from another package must not be matched
+		other := "example.com/legacy.Order"
+		instance.cache.Set("example.com/legacy.Customer", Metadata{
+			FQDN: "example.com/legacy.Customer",
+			Relationships: []TypeRelationship{
+				{From: "example.com/legacy.Customer", To: other, Field: "Orders", Kind: RelationshipCollection, ToPackage: "example.com/legacy"},
+			},
+		})
+
+		for _, ref := range GetReferencedBy[Order]() {
+			if ref.To != orderMeta.FQDN {
+				t.Errorf("expected only references to %s, got %s from %s", orderMeta.FQDN, ref.To, ref.From)
+			}
+		}
+	})
+
 	t.Run("CircularReferences", func(t *testing.T) {
 		// Note: We can't test true circular references in a single test
 		// because Go doesn't allow forward type declarations.
@@ -546,6 +611,99 @@ func TestDetectModulePath(t *testing.T) {
 	}
 }
 
+func TestDetectModulePathFallbacks(t *testing.T) {
+	original := readBuildInfo
+	defer func() { readBuildInfo = original }()
+
+	t.Run("build info unavailable", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+		if path := detectModulePath(); path != "" {
+			t.Errorf("expected empty path, got %q", path)
+		}
+	})
+
+	t.Run("nil build info", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, true }
+		if path := detectModulePath(); path != "" {
+			t.Errorf("expected empty path, got %q", path)
+		}
+	})
+
+	t.Run("build info panics", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { panic("unsupported") }
+		if path := detectModulePath(); path != "" {
+			t.Errorf("expected empty path, got %q", path)
+		}
+	})
+}
+
+func TestScanWithoutModulePath(t *testing.T) {
+	cache := NewCache()
+	s := &Sentinel{
+		cache:          cache,
+		registeredTags: make(map[string]bool),
+	}
+
+	metadata := s.extractMetadataInternal(reflect.TypeOf(User{}), newVisitedSet(), 0)
+
+	if len(metadata.Relationships) == 0 {
+		t.Error("expected relationships to be extracted without a module path")
+	}
+	if cache.Size() != 1 {
+		t.Errorf("expected Scan to degrade to Inspect and cache 1 type, got %d", cache.Size())
+	}
+}
+
+func TestScanWithProgress(t *testing.T) {
+	instance.cache.Clear()
+
+	seen := make(map[string]int)
+	var totals []int
+	metadata := ScanWithProgress[User](func(discovered string, total int) {
+		seen[discovered]++
+		totals = append(totals, total)
+	})
+
+	if metadata.TypeName != "User" {
+		t.Errorf("expected User metadata, got %s", metadata.TypeName)
+	}
+
+	// User, Profile, Address, Order, OrderItem, Settings, Data
+	if len(seen) != 7 || len(totals) != 7 {
+		t.Fatalf("expected 7 discoveries, got %d callbacks for %v", len(totals), seen)
+	}
+	for fqdn, count := range seen {
+		if count != 1 {
+			t.Errorf("expected one callback for %s, got %d", fqdn, count)
+		}
+		if _, ok := instance.cache.Get(fqdn); !ok {
+			t.Errorf("expected %s to be cached when reported", fqdn)
+		}
+	}
+	for i, total := range totals {
+		if total != i+1 {
+			t.Errorf("expected running total %d, got %d", i+1, total)
+		}
+	}
+
+	t.Run("cached types not reported", func(t *testing.T) {
+		calls := 0
+		ScanWithProgress[User](func(string, int) { calls++ })
+		if calls != 0 {
+			t.Errorf("expected no callbacks for a cached graph, got %d", calls)
+		}
+	})
+
+	t.Run("panic on non-struct type", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for non-struct type")
+			}
+		}()
+		ScanWithProgress[string](func(string, int) {})
+	})
+}
+
 func TestGetStructTypeFromField(t *testing.T) {
 	s := &Sentinel{}
 
@@ -829,6 +987,274 @@ func TestExtractRelationship(t *testing.T) {
 	})
 }
 
+func TestParseGenericName(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		args []string
+	}{
+		{"User", "User", nil},
+		{"Base[int]", "Base", []string{"int"}},
+		{"Base[github.com/app.ID]", "Base", []string{"github.com/app.ID"}},
+		{"Pair[string,map[string]main.Base[int]]", "Pair", []string{"string", "map[string]main.Base[int]"}},
+		{"Base[func(int, string) error]", "Base", []string{"func(int, string) error"}},
+		{"Base[struct { A int; B int }]", "Base", []string{"struct { A int; B int }"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, args := parseGenericName(tt.name)
+			if base != tt.base {
+				t.Errorf("expected base %q, got %q", tt.base, base)
+			}
+			if len(args) != len(tt.args) {
+				t.Fatalf("expected args %v, got %v", tt.args, args)
+			}
+			for i := range args {
+				if args[i] != tt.args[i] {
+					t.Errorf("expected arg %d %q, got %q", i, tt.args[i], args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedGenericRelationship(t *testing.T) {
+	instance.cache.Clear()
+
+	t.Run("single type argument", func(t *testing.T) {
+		metadata := Inspect[Resource]()
+
+		if len(metadata.Relationships) != 1 {
+			t.Fatalf("expected 1 relationship, got %+v", metadata.Relationships)
+		}
+		rel := metadata.Relationships[0]
+		if rel.Kind != RelationshipEmbedding {
+			t.Errorf("expected embedding, got %s", rel.Kind)
+		}
+		if rel.GenericBase != "Base" {
+			t.Errorf("expected GenericBase 'Base', got %q", rel.GenericBase)
+		}
+		idFQDN := getFQDN(reflect.TypeOf(ResourceID("")))
+		if len(rel.TypeArguments) != 1 || rel.TypeArguments[0] != idFQDN {
+			t.Errorf("expected TypeArguments [%s], got %v", idFQDN, rel.TypeArguments)
+		}
+	})
+
+	t.Run("multiple type arguments", func(t *testing.T) {
+		metadata := Inspect[Entry]()
+
+		if len(metadata.Relationships) != 1 {
+			t.Fatalf("expected 1 relationship, got %+v", metadata.Relationships)
+		}
+		rel := metadata.Relationships[0]
+		if rel.GenericBase != "Keyed" {
+			t.Errorf("expected GenericBase 'Keyed', got %q", rel.GenericBase)
+		}
+		if len(rel.TypeArguments) != 2 || rel.TypeArguments[0] != "string" || rel.TypeArguments[1] != "map[string]int" {
+			t.Errorf("expected TypeArguments [string map[string]int], got %v", rel.TypeArguments)
+		}
+	})
+
+	t.Run("non-generic embedding", func(t *testing.T) {
+		for _, rel := range Inspect[User]().Relationships {
+			if rel.GenericBase != "" || rel.TypeArguments != nil {
+				t.Errorf("expected no generic info on %s, got %q %v", rel.Field, rel.GenericBase, rel.TypeArguments)
+			}
+		}
+	})
+}
+
+func TestGenericInstantiation(t *testing.T) {
+	orderFQDN := getFQDN(reflect.TypeOf(Order{}))
+
+	t.Run("single type argument", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := Scan[Page[Order]]()
+
+		if metadata.GenericBase != "Page" {
+			t.Errorf("expected GenericBase 'Page', got %q", metadata.GenericBase)
+		}
+		if !reflect.DeepEqual(metadata.TypeArguments, []string{orderFQDN}) {
+			t.Errorf("expected TypeArguments [%s], got %v", orderFQDN, metadata.TypeArguments)
+		}
+		if _, ok := instance.cache.Get(orderFQDN); !ok {
+			t.Error("expected Order to be cached")
+		}
+	})
+
+	t.Run("two type arguments", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := Scan[Pair[string, Address]]()
+
+		addressFQDN := getFQDN(reflect.TypeOf(Address{}))
+		if metadata.GenericBase != "Pair" || !reflect.DeepEqual(metadata.TypeArguments, []string{"string", addressFQDN}) {
+			t.Errorf("expected Pair[string, Address], got %q %v", metadata.GenericBase, metadata.TypeArguments)
+		}
+		if _, ok := instance.cache.Get(addressFQDN); !ok {
+			t.Error("expected Address to be cached")
+		}
+	})
+
+	t.Run("type arguments without a relationship", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := Scan[Cursor[Order]]()
+
+		if len(metadata.Relationships) != 0 {
+			t.Errorf("expected no relationships, got %+v", metadata.Relationships)
+		}
+		if _, ok := instance.cache.Get(orderFQDN); !ok {
+			t.Error("expected the type argument to be scanned")
+		}
+	})
+
+	t.Run("non-generic types", func(t *testing.T) {
+		metadata := Inspect[User]()
+		if metadata.GenericBase != "" || metadata.TypeArguments != nil {
+			t.Errorf("expected no generic info, got %q %v", metadata.GenericBase, metadata.TypeArguments)
+		}
+	})
+}
+
+func TestTypeArgumentStructs(t *testing.T) {
+	orderFQDN := getFQDN(reflect.TypeOf(Order{}))
+	typ := reflect.TypeOf(Pair[string, Order]{})
+
+	resolved := typeArgumentStructs(typ, []string{"string", orderFQDN, "github.com/app.Phantom"})
+	if resolved[0] != nil || resolved[1] != reflect.TypeOf(Order{}) || resolved[2] != nil {
+		t.Errorf("expected [nil Order nil], got %v", resolved)
+	}
+}
+
+func TestIgnoreTag(t *testing.T) {
+	type IgnoredTarget struct {
+		ID string
+	}
+	type WithIgnored struct {
+		Kept    *IgnoredTarget `json:"kept"`
+		Ignored *IgnoredTarget `json:"ignored" sentinel:"-"`
+	}
+
+	s := &Sentinel{
+		cache:          instance.cache,
+		registeredTags: instance.registeredTags,
+	}
+
+	t.Run("ignored field produces no relationship", func(t *testing.T) {
+		typ := reflect.TypeOf(WithIgnored{})
+
+		if rel := s.extractRelationship(typ.Field(1), typ.PkgPath()); rel != nil {
+			t.Errorf("expected no relationship for ignored field, got %+v", rel)
+		}
+		if rel := s.extractRelationship(typ.Field(0), typ.PkgPath()); rel == nil {
+			t.Error("expected relationship for untagged field")
+		}
+	})
+
+	t.Run("ignored field is still extracted", func(t *testing.T) {
+		instance.cache.Clear()
+		metadata := Inspect[WithIgnored]()
+
+		if len(metadata.Fields) != 2 {
+			t.Errorf("expected 2 fields, got %d", len(metadata.Fields))
+		}
+		if len(metadata.Relationships) != 1 || metadata.Relationships[0].Field != "Kept" {
+			t.Errorf("expected only the Kept relationship, got %+v", metadata.Relationships)
+		}
+	})
+}
+
+func TestEmbeddedInline(t *testing.T) {
+	type InlineBase struct {
+		ID string `json:"id"`
+	}
+	type InlineOptions struct {
+		Debug bool `json:"debug"`
+	}
+	type NestedBase struct {
+		Name string `json:"name"`
+	}
+	type Embedder struct {
+		InlineBase
+		InlineOptions `json:",omitempty"`
+		NestedBase    `json:"base"`
+	}
+
+	s := &Sentinel{
+		cache:          NewCache(),
+		registeredTags: make(map[string]bool),
+	}
+	typ := reflect.TypeOf(Embedder{})
+
+	expected := map[string]bool{"InlineBase": true, "InlineOptions": true, "NestedBase": false}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		rel := s.extractRelationship(field, typ.PkgPath())
+		if rel == nil || rel.Kind != RelationshipEmbedding {
+			t.Fatalf("expected embedding relationship for %s, got %+v", field.Name, rel)
+		}
+		if rel.EmbeddedInline != expected[field.Name] {
+			t.Errorf("expected %s EmbeddedInline=%v, got %v", field.Name, expected[field.Name], rel.EmbeddedInline)
+		}
+	}
+}
+
+func TestMutualRelationships(t *testing.T) {
+	s := &Sentinel{
+		cache:          NewCache(),
+		registeredTags: make(map[string]bool),
+	}
+
+	citizen := s.extractRelationships(reflect.TypeOf(Citizen{}), nil, 0)
+	passport := s.extractRelationships(reflect.TypeOf(Passport{}), nil, 0)
+	if len(citizen) != 1 || len(passport) != 1 {
+		t.Fatalf("expected one relationship on each side, got %+v and %+v", citizen, passport)
+	}
+	if !citizen[0].Mutual || !passport[0].Mutual {
+		t.Errorf("expected both edges to be mutual, got %+v and %+v", citizen[0], passport[0])
+	}
+
+	// A one-way reference is not mutual
+	profile := s.extractRelationships(reflect.TypeOf(Profile{}), nil, 0)
+	if len(profile) != 1 || profile[0].Mutual {
+		t.Errorf("expected one-way reference, got %+v", profile)
+	}
+
+	// A self-reference is not mutual
+	type Node struct {
+		Parent *Node
+	}
+	node := s.extractRelationships(reflect.TypeOf(Node{}), nil, 0)
+	if len(node) != 1 || node[0].Mutual {
+		t.Errorf("expected self-reference not to be mutual, got %+v", node)
+	}
+}
+
+func TestCardinality(t *testing.T) {
+	s := &Sentinel{
+		cache:          NewCache(),
+		registeredTags: make(map[string]bool),
+	}
+
+	expected := map[string]string{
+		"Profile":  CardinalityOne,  // reference
+		"Orders":   CardinalityMany, // collection
+		"Settings": CardinalityOne,  // embedding
+	}
+	rels := s.extractRelationships(reflect.TypeOf(User{}), nil, 0)
+	rels = append(rels, s.extractRelationships(reflect.TypeOf(Settings{}), nil, 0)...)
+	expected["Metadata"] = CardinalityMany // map
+
+	if len(rels) != len(expected) {
+		t.Fatalf("expected %d relationships, got %+v", len(expected), rels)
+	}
+	for _, rel := range rels {
+		if rel.Cardinality != expected[rel.Field] {
+			t.Errorf("%s (%s): expected cardinality %q, got %q", rel.Field, rel.Kind, expected[rel.Field], rel.Cardinality)
+		}
+	}
+}
+
 func TestExtractRelationshipsEdgeCases(t *testing.T) {
 	t.Run("pointer to non-struct returns empty", func(t *testing.T) {
 		s := &Sentinel{
@@ -841,7 +1267,7 @@ func TestExtractRelationshipsEdgeCases(t *testing.T) {
 		typ := reflect.TypeOf(intPtr)
 
 		// Should return empty slice after dereferencing pointer to non-struct
-		relationships := s.extractRelationships(typ, nil)
+		relationships := s.extractRelationships(typ, nil, 0)
 
 		if len(relationships) != 0 {
 			t.Errorf("expected 0 relationships for pointer to non-struct, got %d", len(relationships))
@@ -857,7 +1283,7 @@ func TestExtractRelationshipsEdgeCases(t *testing.T) {
 		// Direct non-struct type
 		typ := reflect.TypeOf(42)
 
-		relationships := s.extractRelationships(typ, nil)
+		relationships := s.extractRelationships(typ, nil, 0)
 
 		if len(relationships) != 0 {
 			t.Errorf("expected 0 relationships for non-struct, got %d", len(relationships))
@@ -885,10 +1311,10 @@ func TestExtractRelationshipsScanMode(t *testing.T) {
 		typ := reflect.TypeOf(Outer{})
 		innerType := reflect.TypeOf(Inner{})
 		innerFQDN := getFQDN(innerType)
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
-		// Extract relationships in Scan mode (with visited map)
-		relationships := s.extractRelationships(typ, visited)
+		// Extract relationships in Scan mode (with visited set)
+		relationships := s.extractRelationships(typ, visited, 0)
 
 		// Should find the relationship to Inner
 		if len(relationships) != 1 {
@@ -896,7 +1322,7 @@ func TestExtractRelationshipsScanMode(t *testing.T) {
 		}
 
 		// Inner should have been extracted recursively (using FQDN)
-		if !visited[innerFQDN] {
+		if !visited.Has(innerFQDN) {
 			t.Errorf("expected Inner (%s) to be visited during Scan mode", innerFQDN)
 		}
 
@@ -925,8 +1351,8 @@ func TestExtractRelationshipsScanMode(t *testing.T) {
 		innerType := reflect.TypeOf(InnerB{})
 		innerFQDN := getFQDN(innerType)
 
-		// Extract relationships in Inspect mode (nil visited map)
-		relationships := s.extractRelationships(typ, nil)
+		// Extract relationships in Inspect mode (nil visited set)
+		relationships := s.extractRelationships(typ, nil, 0)
 
 		// Should find the relationship to InnerB
 		if len(relationships) != 1 {
@@ -953,10 +1379,10 @@ func TestExtractRelationshipsScanMode(t *testing.T) {
 		}
 
 		typ := reflect.TypeOf(OuterC{})
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
 		// Should handle nil relType gracefully
-		relationships := s.extractRelationships(typ, visited)
+		relationships := s.extractRelationships(typ, visited, 0)
 
 		// No relationships for interface fields
 		if len(relationships) != 0 {
@@ -986,10 +1412,10 @@ func TestExtractRelationshipsScanMode(t *testing.T) {
 		typ := reflect.TypeOf(Container{})
 		localType := reflect.TypeOf(LocalType{})
 		localFQDN := getFQDN(localType)
-		visited := make(map[string]bool)
+		visited := newVisitedSet()
 
 		// Extract relationships - LocalType is in same module so should recurse
-		relationships := s.extractRelationships(typ, visited)
+		relationships := s.extractRelationships(typ, visited, 0)
 
 		if len(relationships) != 1 {
 			t.Fatalf("expected 1 relationship, got %d", len(relationships))
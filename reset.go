@@ -2,7 +2,7 @@
 
 package sentinel
 
-// Reset clears the cache and tag registry.
+// Reset clears the cache, tag registry, extraction options and virtual fields.
 // This function is only available when building with -tags testing.
 // It is intended for test isolation and should never be used in production.
 func Reset() {
@@ -11,4 +11,29 @@ func Reset() {
 
 	instance.cache = NewCache()
 	instance.registeredTags = make(map[string]bool)
+	instance.tagAliases = nil
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.maxFields = 0
+	instance.maxDepth = 0
+	instance.markers = nil
+	instance.virtualFields = nil
+	instance.nullableTypes = nil
+	instance.scalarFormats = nil
+	instance.enums = nil
+	instance.codecTags = nil
+	instance.modulePaths = nil
+	instance.joinDetection = false
+	instance.flattenEmbedded = false
+	instance.unexportedFields = false
+	instance.uuidSupport = false
+	instance.sourceRoot = ""
+	instance.sourceModule = ""
+
+	instance.sourceMutex.Lock()
+	defer instance.sourceMutex.Unlock()
+
+	instance.sourceDocs = nil
 }
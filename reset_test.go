@@ -42,6 +42,13 @@ func TestReset(t *testing.T) {
 			t.Error("expected tag registry to be cleared after reset")
 		}
 
+		// Verify extraction options are restored to defaults
+		SetMaxFields(5)
+		Reset()
+		if instance.maxFields != 0 {
+			t.Errorf("expected maxFields to be reset, got %d", instance.maxFields)
+		}
+
 		// Verify Browse returns empty
 		types := Browse()
 		if len(types) != 0 {
@@ -0,0 +1,61 @@
+package sentinel
+
+import "reflect"
+
+// uuidFQDN is the FQDN of github.com/google/uuid.UUID, matched by name so the
+// package is not imported.
+const uuidFQDN = "github.com/google/uuid.UUID"
+
+// RegisterScalarType declares the named type t as a scalar with the given
+// schema format, such as "uuid" for a [16]byte identifier or "decimal" for a
+// decimal struct. Fields of type t are reported as KindScalar with Format set,
+// rather than as slices or structs, and never form relationships.
+// GenerateJSONSchema writes them as strings of that format. Pointer types
+// register their element type; a *T field stays a pointer. Registering a type
+// again replaces its format. Only affects types extracted after the call.
+func RegisterScalarType(t reflect.Type, format string) {
+	fqdn := getFQDN(t)
+
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	if instance.scalarFormats == nil {
+		instance.scalarFormats = make(map[string]string)
+	}
+	instance.scalarFormats[fqdn] = format
+}
+
+// SetUUIDSupport enables or disables treating github.com/google/uuid.UUID as a
+// scalar with format "uuid", which is off by default. The type is recognized by
+// its package and name, so sentinel does not depend on the uuid module. A
+// registration with RegisterScalarType takes precedence. Only affects types
+// extracted after the call.
+func SetUUIDSupport(enabled bool) {
+	instance.configMutex.Lock()
+	defer instance.configMutex.Unlock()
+
+	instance.uuidSupport = enabled
+}
+
+// scalarFormat returns the format of a registered scalar type. The bool is
+// false if t is not a registered named type.
+func (s *Sentinel) scalarFormat(t reflect.Type) (string, bool) {
+	if t == nil || t.Kind() == reflect.Ptr || t.Name() == "" {
+		return "", false
+	}
+	return s.scalarFormatByFQDN(getFQDN(t))
+}
+
+// scalarFormatByFQDN looks up the format of a registered scalar type by FQDN.
+func (s *Sentinel) scalarFormatByFQDN(fqdn string) (string, bool) {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	if format, ok := s.scalarFormats[fqdn]; ok {
+		return format, true
+	}
+	if s.uuidSupport && fqdn == uuidFQDN {
+		return "uuid", true
+	}
+	return "", false
+}
@@ -0,0 +1,83 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type ScalarUUID [16]byte
+
+type ScalarDecimal struct {
+	Digits string
+}
+
+type ScalarRecord struct {
+	ID      ScalarUUID    `json:"id"`
+	Parent  *ScalarUUID   `json:"parent"`
+	Amount  ScalarDecimal `json:"amount"`
+	Amounts []ScalarDecimal
+}
+
+func TestRegisterScalarType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("unregistered types keep their kind", func(t *testing.T) {
+		metadata := Inspect[ScalarRecord]()
+		fields := nullableFields(metadata.Fields)
+		if fields["ID"].Kind != KindSlice || fields["ID"].Format != "" {
+			t.Errorf("expected byte array to be a slice, got %+v", fields["ID"])
+		}
+		if len(metadata.Relationships) != 2 {
+			t.Errorf("expected decimal relationships, got %+v", metadata.Relationships)
+		}
+	})
+
+	Reset()
+	RegisterScalarType(reflect.TypeOf(ScalarUUID{}), "uuid")
+	RegisterScalarType(reflect.TypeOf(&ScalarDecimal{}), "decimal")
+
+	metadata := Inspect[ScalarRecord]()
+	fields := nullableFields(metadata.Fields)
+
+	t.Run("registered types are scalars", func(t *testing.T) {
+		for name, format := range map[string]string{"ID": "uuid", "Amount": "decimal"} {
+			if field := fields[name]; field.Kind != KindScalar || field.Format != format {
+				t.Errorf("field %s: expected scalar %s, got kind=%s format=%q", name, format, field.Kind, field.Format)
+			}
+		}
+		if field := fields["Parent"]; field.Kind != KindPointer {
+			t.Errorf("expected pointer to stay a pointer, got %s", field.Kind)
+		}
+	})
+
+	t.Run("registered types form no relationships", func(t *testing.T) {
+		if len(metadata.Relationships) != 0 {
+			t.Errorf("expected no relationships, got %+v", metadata.Relationships)
+		}
+	})
+
+	t.Run("json schema uses the format", func(t *testing.T) {
+		g := &jsonSchemaGenerator{}
+		schema := g.valueSchema(reflect.TypeOf(ScalarUUID{}))
+		if schema["type"] != "string" || schema["format"] != "uuid" {
+			t.Errorf("expected uuid string, got %v", schema)
+		}
+	})
+}
+
+func TestUUIDSupport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if _, ok := instance.scalarFormatByFQDN(uuidFQDN); ok {
+		t.Error("expected uuid.UUID not to be a scalar by default")
+	}
+
+	SetUUIDSupport(true)
+	if format, ok := instance.scalarFormatByFQDN(uuidFQDN); !ok || format != "uuid" {
+		t.Errorf("expected uuid.UUID to be a uuid scalar, got %q %v", format, ok)
+	}
+}
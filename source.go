@@ -0,0 +1,266 @@
+package sentinel
+
+import (
+	"bufio"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// SetSourceComments enables reading doc comments from source, which is off by
+// default. dir is the root of the module's source, the directory holding its
+// go.mod; pass "" to disable. When enabled, Metadata.Doc and FieldMetadata.Doc
+// are populated from the comments on type and field declarations:
+//
+//	sentinel.SetSourceComments(".")
+//
+// Only types declared at package level in packages of that module are
+// documented, from files that build for the current platform and build tags;
+// _test.go files are read only for types of external test packages. A field's
+// Doc is its doc comment, or its trailing line comment when it has none. Each
+// package is parsed once and kept for later types; packages whose source
+// cannot be read leave Doc empty. Only affects types extracted after the call.
+func SetSourceComments(dir string) {
+	module := ""
+	if dir != "" {
+		module = readModulePath(filepath.Join(dir, "go.mod"))
+	}
+
+	instance.configMutex.Lock()
+	instance.sourceRoot = dir
+	instance.sourceModule = module
+	instance.configMutex.Unlock()
+
+	instance.sourceMutex.Lock()
+	instance.sourceDocs = nil
+	instance.sourceMutex.Unlock()
+}
+
+// packageDocs holds the doc comments of one package's type declarations.
+type packageDocs map[string]typeDocs
+
+// typeDocs holds the doc comments of a type and its fields.
+type typeDocs struct {
+	fields map[string]string
+	doc    string
+}
+
+// readModulePath returns the module path declared in a go.mod file, or "" if
+// it cannot be read.
+func readModulePath(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module"); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+	return ""
+}
+
+// applySourceDocs fills in the doc comments of a type and its fields when
+// source comments are enabled.
+func (s *Sentinel) applySourceDocs(t reflect.Type, metadata *Metadata) {
+	docs := s.packageDocsFor(t.PkgPath())
+	if docs == nil {
+		return
+	}
+
+	name, _ := parseGenericName(t.Name())
+	metadata.Doc = docs[name].doc
+
+	for i := range metadata.Fields {
+		field := &metadata.Fields[i]
+		if len(field.Index) == 0 {
+			continue
+		}
+		// Promoted fields are documented on the struct that declares them
+		owner := declaringType(t, field.Index)
+		if owner == nil {
+			continue
+		}
+		ownerDocs := docs
+		if owner.PkgPath() != t.PkgPath() {
+			if ownerDocs = s.packageDocsFor(owner.PkgPath()); ownerDocs == nil {
+				continue
+			}
+		}
+		ownerName, _ := parseGenericName(owner.Name())
+		field.Doc = ownerDocs[ownerName].fields[field.Name]
+
+		// A deprecated tag takes precedence over the doc comment
+		if note, ok := deprecationNote(field.Doc); ok && !field.Deprecated {
+			field.Deprecated = true
+			field.DeprecationNote = note
+		}
+	}
+}
+
+// deprecationNote returns the paragraph of a doc comment that starts with
+// "Deprecated: ", the convention Go tooling recognizes, without that prefix.
+func deprecationNote(doc string) (string, bool) {
+	for _, paragraph := range strings.Split(doc, "\n\n") {
+		if note, ok := strings.CutPrefix(paragraph, "Deprecated: "); ok {
+			return strings.Join(strings.Fields(note), " "), true
+		}
+	}
+	return "", false
+}
+
+// declaringType returns the struct type that declares the field at index,
+// following embedded fields (and pointers to them) along the path.
+func declaringType(t reflect.Type, index []int) reflect.Type {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+	}
+	return t
+}
+
+// packageDocsFor returns the parsed doc comments of a package in the source
+// module, parsing it on first use. Returns nil when source comments are
+// disabled or the package is outside the module or unreadable.
+func (s *Sentinel) packageDocsFor(pkgPath string) packageDocs {
+	s.configMutex.RLock()
+	root, module := s.sourceRoot, s.sourceModule
+	s.configMutex.RUnlock()
+
+	if root == "" || module == "" || pkgPath == "" {
+		return nil
+	}
+
+	// External test packages live in the directory of the package they test
+	base, external := strings.CutSuffix(pkgPath, "_test")
+	if !withinModule(base, module) {
+		return nil
+	}
+	dir := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(base, module)))
+
+	s.sourceMutex.Lock()
+	defer s.sourceMutex.Unlock()
+
+	if docs, ok := s.sourceDocs[pkgPath]; ok {
+		return docs
+	}
+	docs := parsePackageDocs(dir, external)
+	if s.sourceDocs == nil {
+		s.sourceDocs = make(map[string]packageDocs)
+	}
+	s.sourceDocs[pkgPath] = docs
+	return docs
+}
+
+// parsePackageDocs collects the doc comments of the package-level types
+// declared in a directory's Go files. Only files that build for the current
+// platform and build tags are read, and _test.go files are skipped, so test
+// types and declarations for other platforms do not leak in. external selects
+// the _test.go files of the external test package (package name ending in
+// _test) instead. Files that fail to parse are skipped.
+func parsePackageDocs(dir string, external bool) packageDocs {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	docs := make(packageDocs)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") != external {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil || strings.HasSuffix(file.Name.Name, "_test") != external {
+			continue
+		}
+		collectTypeDocs(file, docs)
+	}
+	return docs
+}
+
+// collectTypeDocs records the doc comments of a file's package-level types.
+func collectTypeDocs(file *ast.File, docs packageDocs) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+
+			doc := typeSpec.Doc
+			// A lone type declaration carries its comment on the declaration
+			if doc == nil && !gen.Lparen.IsValid() {
+				doc = gen.Doc
+			}
+			entry := typeDocs{doc: commentText(doc), fields: make(map[string]string)}
+
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				for _, field := range structType.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+					for _, name := range fieldNames(field) {
+						entry.fields[name] = text
+					}
+				}
+			}
+			docs[typeSpec.Name.Name] = entry
+		}
+	}
+}
+
+// fieldNames returns the names a struct field declares, including the implicit
+// name of an embedded field.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		return names
+	}
+
+	// Embedded: T, *T, pkg.T, T[A] and combinations
+	expr := field.Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return []string{e.Sel.Name}
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return []string{e.Name}
+		default:
+			return nil
+		}
+	}
+}
+
+// commentText returns the text of a comment group without surrounding space.
+func commentText(group *ast.CommentGroup) string {
+	return strings.TrimSpace(group.Text())
+}
@@ -0,0 +1,135 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sourceFixtures is the module root holding the doc comments of the fixtures
+// below. Test files are never read for docs, so the documented declarations
+// live in testdata/source/documented.go, alongside a copy excluded by a build
+// constraint and one in a test file that must both be ignored.
+const sourceFixtures = "testdata/source"
+
+type DocumentedBase struct {
+	ID string
+}
+
+type DocumentedWidget struct {
+	DocumentedBase
+	Name  string
+	Count int
+	Plain bool
+}
+
+type DocumentedLegacy struct {
+	Old    string
+	Tagged string `deprecated:"use the tag note"`
+	New    string
+}
+
+type DocumentedGrouped struct {
+	A, B string
+}
+
+func docsByField(metadata Metadata) map[string]string {
+	docs := make(map[string]string, len(metadata.Fields))
+	for _, field := range metadata.Fields {
+		docs[field.Name] = field.Doc
+	}
+	return docs
+}
+
+func TestSourceComments(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		if doc := Inspect[DocumentedWidget]().Doc; doc != "" {
+			t.Errorf("expected no doc, got %q", doc)
+		}
+	})
+
+	Reset()
+	SetSourceComments(sourceFixtures)
+
+	t.Run("type and field docs", func(t *testing.T) {
+		metadata := Inspect[DocumentedWidget]()
+		if metadata.Doc != "DocumentedWidget is a fixture with doc comments." {
+			t.Errorf("unexpected type doc: %q", metadata.Doc)
+		}
+
+		expected := map[string]string{
+			"DocumentedBase": "",
+			"Name":           "Name is the display name.",
+			"Count":          "Count is a trailing comment.",
+			"Plain":          "",
+		}
+		if got := docsByField(metadata); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("grouped declarations", func(t *testing.T) {
+		metadata := Inspect[DocumentedGrouped]()
+		if metadata.Doc != "DocumentedGrouped is declared in a type group." {
+			t.Errorf("unexpected type doc: %q", metadata.Doc)
+		}
+		for name, doc := range docsByField(metadata) {
+			if doc != "A and B share a comment." {
+				t.Errorf("field %s: unexpected doc %q", name, doc)
+			}
+		}
+	})
+
+	t.Run("promoted fields use the declaring struct", func(t *testing.T) {
+		SetFlattenEmbedded(true)
+		defer SetFlattenEmbedded(false)
+		instance.cache.Delete(getFQDN(reflect.TypeOf(DocumentedWidget{})))
+
+		if doc := docsByField(Inspect[DocumentedWidget]())["ID"]; doc != "ID identifies the widget." {
+			t.Errorf("unexpected promoted field doc: %q", doc)
+		}
+	})
+
+	t.Run("deprecation paragraphs", func(t *testing.T) {
+		expected := map[string]string{
+			"Old":    "use New instead.",
+			"Tagged": "use the tag note",
+		}
+		for _, field := range Inspect[DocumentedLegacy]().Fields {
+			note, deprecated := expected[field.Name]
+			if field.Deprecated != deprecated || field.DeprecationNote != note {
+				t.Errorf("field %s: expected deprecated=%v note=%q, got deprecated=%v note=%q",
+					field.Name, deprecated, note, field.Deprecated, field.DeprecationNote)
+			}
+		}
+	})
+
+	t.Run("packages are parsed once", func(t *testing.T) {
+		instance.sourceMutex.Lock()
+		parsed := len(instance.sourceDocs)
+		instance.sourceMutex.Unlock()
+		if parsed != 1 {
+			t.Errorf("expected one parsed package, got %d", parsed)
+		}
+	})
+
+	t.Run("types outside the module", func(t *testing.T) {
+		if docs := instance.packageDocsFor("time"); docs != nil {
+			t.Errorf("expected no docs outside the module, got %v", docs)
+		}
+	})
+
+	Reset()
+	SetSourceComments("testdata/missing")
+
+	t.Run("missing source leaves docs empty", func(t *testing.T) {
+		metadata := Inspect[DocumentedWidget]()
+		if metadata.Doc != "" || metadata.Fields[1].Doc != "" {
+			t.Errorf("expected empty docs, got %+v", metadata)
+		}
+	})
+}
@@ -0,0 +1,416 @@
+package sentinel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SQLDialect selects the SQL flavor emitted by GenerateSQL.
+type SQLDialect string
+
+// Supported SQL dialects.
+const (
+	SQLPostgres SQLDialect = "postgres"
+	SQLMySQL    SQLDialect = "mysql"
+)
+
+// sqlColumn is a column of a generated table.
+type sqlColumn struct {
+	Name    string
+	Type    string
+	GoName  string
+	NotNull bool
+}
+
+// sqlForeignKey is a foreign-key constraint of a generated table.
+type sqlForeignKey struct {
+	Table     string
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// sqlTable is a generated table.
+type sqlTable struct {
+	Name    string
+	Columns []sqlColumn
+	Primary []string
+}
+
+// GenerateSQL renders CREATE TABLE statements for T and every cached type
+// reachable from it, followed by the foreign-key constraints between them.
+// Constraints are added with ALTER TABLE after all tables exist, so cyclic
+// references are supported.
+//
+// Each struct becomes a table named after the snake_case type name. Columns
+// are named by the db tag, falling back to the snake_case field name, and
+// fields tagged `db:"-"` are skipped. Scalars map to column types by their Go
+// kind, time.Time to a timestamp, []byte to a binary column and nullable
+// wrappers to their underlying type; registered scalar types are text, or
+// UUID columns for the "uuid" format; other values that are not relationships,
+// such as maps, are stored as JSON. Columns are NOT NULL unless the field is
+// Nullable, and a column for a field named ID is the primary key.
+//
+// Relationships shape the schema: embedded structs contribute their columns
+// and get no table of their own unless another type refers to them,
+// references to a type with an ID become a foreign-key column named
+// <field>_id (unless db-tagged), and collections of such types become a join
+// table named <table>_<field> keyed by both IDs. Relationships to types
+// without an ID are stored as JSON.
+//
+// Scan T first: returns ErrNotCached listing every relationship target that
+// is not cached, and an error for unknown dialects.
+func GenerateSQL[T any](dialect SQLDialect) (string, error) {
+	if dialect != SQLPostgres && dialect != SQLMySQL {
+		return "", fmt.Errorf("sentinel: unsupported SQL dialect %q", dialect)
+	}
+
+	var zero T
+	t, err := structType(reflect.TypeOf(zero))
+	if err != nil {
+		return "", err
+	}
+
+	steps, err := LoadOrder(getFQDN(t))
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	types := make([]Metadata, 0, len(steps))
+	for _, step := range steps {
+		metadata, exists := instance.cache.Get(step.FQDN)
+		if !exists {
+			missing = append(missing, step.FQDN)
+			continue
+		}
+		types = append(types, metadata)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("%w: %s", ErrNotCached, strings.Join(missing, ", "))
+	}
+
+	// Types only ever embedded are inlined into their embedders' tables
+	stored := map[string]bool{steps[0].FQDN: true}
+	g := &sqlGenerator{dialect: dialect, types: make(map[string]Metadata, len(types))}
+	for _, metadata := range types {
+		g.types[metadata.FQDN] = metadata
+		for _, rel := range metadata.Relationships {
+			if rel.Kind != RelationshipEmbedding {
+				stored[rel.To] = true
+			}
+		}
+	}
+
+	var tables []sqlTable
+	var joins []sqlTable
+	var keys []sqlForeignKey
+	for _, metadata := range types {
+		if !stored[metadata.FQDN] {
+			continue
+		}
+		table, tableJoins, tableKeys := g.table(metadata)
+		tables = append(tables, table)
+		joins = append(joins, tableJoins...)
+		keys = append(keys, tableKeys...)
+	}
+
+	var b strings.Builder
+	for _, table := range append(tables, joins...) {
+		g.writeTable(&b, table)
+	}
+	for _, key := range keys {
+		g.writeForeignKey(&b, key)
+	}
+	return b.String(), nil
+}
+
+// sqlGenerator renders tables for one dialect.
+type sqlGenerator struct {
+	dialect SQLDialect
+	types   map[string]Metadata // FQDN -> metadata, read from the cache once
+}
+
+// table builds the table of a type along with its join tables and foreign keys.
+func (g *sqlGenerator) table(metadata Metadata) (sqlTable, []sqlTable, []sqlForeignKey) {
+	table := sqlTable{Name: snakeCase(metadata.TypeName)}
+	var joins []sqlTable
+	var keys []sqlForeignKey
+
+	for _, field := range g.columnFields(metadata) {
+		rel := fieldRelationship(field.owner, field.Name)
+		columnName := g.columnName(field.FieldMetadata)
+
+		switch {
+		case rel != nil && rel.Kind == RelationshipReference:
+			target := g.types[rel.To]
+			id, ok := g.idColumn(target)
+			if !ok {
+				break
+			}
+			if _, tagged := field.Tags["db"]; !tagged {
+				columnName = snakeCase(field.Name) + "_id"
+			}
+			table.Columns = append(table.Columns, sqlColumn{
+				Name:    columnName,
+				Type:    id.Type,
+				GoName:  field.Name,
+				NotNull: !field.Nullable,
+			})
+			keys = append(keys, sqlForeignKey{
+				Table:     table.Name,
+				Column:    columnName,
+				RefTable:  snakeCase(target.TypeName),
+				RefColumn: id.Name,
+			})
+			continue
+
+		case rel != nil && rel.Kind == RelationshipCollection:
+			target := g.types[rel.To]
+			targetID, targetOK := g.idColumn(target)
+			ownID, ownOK := g.idColumn(metadata)
+			if !targetOK || !ownOK {
+				break
+			}
+			join, joinKeys := g.joinTable(table.Name, field.Name, ownID, snakeCase(target.TypeName), targetID)
+			joins = append(joins, join)
+			keys = append(keys, joinKeys...)
+			continue
+		}
+
+		table.Columns = append(table.Columns, sqlColumn{
+			Name:    columnName,
+			Type:    g.columnType(field.FieldMetadata),
+			GoName:  field.Name,
+			NotNull: !field.Nullable,
+		})
+	}
+
+	for _, column := range table.Columns {
+		if column.GoName == "ID" {
+			table.Primary = []string{column.Name}
+			break
+		}
+	}
+	return table, joins, keys
+}
+
+// sqlField is a field contributing a column, with the metadata that declares it.
+type sqlField struct {
+	FieldMetadata
+	owner Metadata
+}
+
+// columnFields returns the fields that contribute columns to a type's table,
+// replacing untagged embedded structs with their own column fields.
+func (g *sqlGenerator) columnFields(metadata Metadata) []sqlField {
+	var fields []sqlField
+	for _, field := range metadata.Fields {
+		// Promoted fields are reached through their embedding field
+		if !field.Exported || len(field.Index) > 1 || field.Tags["db"] == "-" {
+			continue
+		}
+
+		if rel := fieldRelationship(metadata, field.Name); rel != nil && rel.Kind == RelationshipEmbedding && field.Tags["db"] == "" {
+			if embedded, exists := instance.cache.Get(rel.To); exists {
+				fields = append(fields, g.columnFields(embedded)...)
+				continue
+			}
+		}
+		fields = append(fields, sqlField{FieldMetadata: field, owner: metadata})
+	}
+	return fields
+}
+
+// idColumn returns the column of a type's ID field, if it has one.
+func (g *sqlGenerator) idColumn(metadata Metadata) (sqlColumn, bool) {
+	for _, field := range g.columnFields(metadata) {
+		if field.Name == "ID" {
+			return sqlColumn{Name: g.columnName(field.FieldMetadata), Type: g.columnType(field.FieldMetadata)}, true
+		}
+	}
+	return sqlColumn{}, false
+}
+
+// joinTable builds the join table for a collection relationship.
+func (*sqlGenerator) joinTable(owner, field string, ownerID sqlColumn, target string, targetID sqlColumn) (sqlTable, []sqlForeignKey) {
+	name := owner + "_" + snakeCase(field)
+	ownerColumn := owner + "_" + ownerID.Name
+	targetColumn := target + "_" + targetID.Name
+	if ownerColumn == targetColumn {
+		targetColumn = snakeCase(field) + "_" + targetID.Name
+	}
+
+	join := sqlTable{
+		Name: name,
+		Columns: []sqlColumn{
+			{Name: ownerColumn, Type: ownerID.Type, NotNull: true},
+			{Name: targetColumn, Type: targetID.Type, NotNull: true},
+		},
+		Primary: []string{ownerColumn, targetColumn},
+	}
+	keys := []sqlForeignKey{
+		{Table: name, Column: ownerColumn, RefTable: owner, RefColumn: ownerID.Name},
+		{Table: name, Column: targetColumn, RefTable: target, RefColumn: targetID.Name},
+	}
+	return join, keys
+}
+
+// fieldRelationship returns the relationship created by a field, if any.
+func fieldRelationship(metadata Metadata, field string) *TypeRelationship {
+	for i := range metadata.Relationships {
+		if metadata.Relationships[i].Field == field {
+			return &metadata.Relationships[i]
+		}
+	}
+	return nil
+}
+
+// columnName returns the db tag name of a field, or its snake_case name.
+func (*sqlGenerator) columnName(field FieldMetadata) string {
+	if tag, ok := field.Tag("db"); ok && tag.Name != "" {
+		return tag.Name
+	}
+	return snakeCase(field.Name)
+}
+
+// columnType maps a field to a column type.
+func (g *sqlGenerator) columnType(field FieldMetadata) string {
+	t := field.ReflectType
+	if t == nil {
+		t = scalarTypes[field.Type]
+	}
+	if t == nil {
+		return g.jsonType()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if format, ok := instance.scalarFormat(t); ok {
+		if format == "uuid" {
+			return g.pick("UUID", "CHAR(36)")
+		}
+		return g.pick("TEXT", "VARCHAR(255)")
+	}
+
+	if t.Kind() == reflect.Struct {
+		if getFQDN(t) == timeFQDN {
+			return g.pick("TIMESTAMPTZ", "DATETIME")
+		}
+		if underlying, ok := instance.nullableUnderlying(t); ok {
+			if underlying == timeFQDN {
+				return g.pick("TIMESTAMPTZ", "DATETIME")
+			}
+			if scalar, ok := scalarTypes[underlying]; ok {
+				t = scalar
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT"
+	case reflect.Int32, reflect.Uint16:
+		return g.pick("INTEGER", "INT")
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return "BIGINT"
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return g.pick("NUMERIC(20)", "BIGINT UNSIGNED")
+	case reflect.Float32:
+		return g.pick("REAL", "FLOAT")
+	case reflect.Float64:
+		return g.pick("DOUBLE PRECISION", "DOUBLE")
+	case reflect.String:
+		return g.pick("TEXT", "VARCHAR(255)")
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return g.pick("BYTEA", "BLOB")
+		}
+	}
+	return g.jsonType()
+}
+
+// jsonType returns the column type for values stored as JSON.
+func (g *sqlGenerator) jsonType() string {
+	return g.pick("JSONB", "JSON")
+}
+
+// pick returns the Postgres or MySQL variant for the generator's dialect.
+func (g *sqlGenerator) pick(postgres, mysql string) string {
+	if g.dialect == SQLMySQL {
+		return mysql
+	}
+	return postgres
+}
+
+// quote quotes an identifier for the generator's dialect.
+func (g *sqlGenerator) quote(name string) string {
+	if g.dialect == SQLMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// writeTable writes a CREATE TABLE statement.
+func (g *sqlGenerator) writeTable(b *strings.Builder, table sqlTable) {
+	fmt.Fprintf(b, "CREATE TABLE %s (\n", g.quote(table.Name))
+
+	lines := make([]string, 0, len(table.Columns)+1)
+	for _, column := range table.Columns {
+		line := "  " + g.quote(column.Name) + " " + column.Type
+		if column.NotNull {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+	if len(table.Primary) > 0 {
+		quoted := make([]string, len(table.Primary))
+		for i, column := range table.Primary {
+			quoted[i] = g.quote(column)
+		}
+		lines = append(lines, "  PRIMARY KEY ("+strings.Join(quoted, ", ")+")")
+	}
+
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n);\n\n")
+}
+
+// writeForeignKey writes an ALTER TABLE statement adding a foreign key.
+func (g *sqlGenerator) writeForeignKey(b *strings.Builder, key sqlForeignKey) {
+	fmt.Fprintf(b, "ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);\n",
+		g.quote(key.Table),
+		g.quote("fk_"+key.Table+"_"+key.Column),
+		g.quote(key.Column),
+		g.quote(key.RefTable),
+		g.quote(key.RefColumn),
+	)
+}
+
+// snakeCase converts a Go identifier to snake_case, keeping initialisms
+// together: UserID becomes user_id and HTTPServer becomes http_server.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
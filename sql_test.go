@@ -0,0 +1,155 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SQLBase struct {
+	ID      int64     `db:"id"`
+	Created time.Time `db:"created_at"`
+}
+
+type SQLUser struct {
+	SQLBase
+	Email    string            `db:"email"`
+	Nickname *string           `db:"nickname"`
+	Profile  *SQLProfile       `db:"profile_id"`
+	Manager  SQLProfile        // untagged reference
+	Groups   []SQLGroup        // collection
+	Note     sql.NullString    `db:"note"`
+	Settings map[string]string `db:"settings"`
+	Secret   string            `db:"-"`
+	Score    uint64
+}
+
+type SQLProfile struct {
+	ID  int64  `db:"id"`
+	Bio string `db:"bio"`
+}
+
+type SQLGroup struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestGenerateSQL(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	t.Run("rejects unknown dialects", func(t *testing.T) {
+		if _, err := GenerateSQL[SQLUser]("sqlite"); err == nil {
+			t.Error("expected error for unknown dialect")
+		}
+	})
+
+	t.Run("requires cached root", func(t *testing.T) {
+		if _, err := GenerateSQL[SQLUser](SQLPostgres); !errors.Is(err, ErrNotCached) {
+			t.Errorf("expected ErrNotCached, got %v", err)
+		}
+	})
+
+	t.Run("lists unresolved targets", func(t *testing.T) {
+		Inspect[SQLUser]()
+		_, err := GenerateSQL[SQLUser](SQLPostgres)
+		if !errors.Is(err, ErrNotCached) {
+			t.Fatalf("expected ErrNotCached, got %v", err)
+		}
+		for _, fqdn := range []string{
+			getFQDN(reflect.TypeOf(SQLProfile{})),
+			getFQDN(reflect.TypeOf(SQLGroup{})),
+			getFQDN(reflect.TypeOf(SQLBase{})),
+		} {
+			if !strings.Contains(err.Error(), fqdn) {
+				t.Errorf("expected %s in error, got %v", fqdn, err)
+			}
+		}
+	})
+
+	Scan[SQLUser]()
+
+	t.Run("postgres", func(t *testing.T) {
+		ddl, err := GenerateSQL[SQLUser](SQLPostgres)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{
+			`CREATE TABLE "sql_user" (`,
+			`  "id" BIGINT NOT NULL,`,
+			`  "created_at" TIMESTAMPTZ NOT NULL,`,
+			`  "email" TEXT NOT NULL,`,
+			`  "nickname" TEXT,`,
+			`  "profile_id" BIGINT,`,
+			`  "manager_id" BIGINT NOT NULL,`,
+			`  "note" TEXT,`,
+			`  "settings" JSONB,`,
+			`  "score" NUMERIC(20) NOT NULL,`,
+			`  PRIMARY KEY ("id")`,
+			`CREATE TABLE "sql_profile" (`,
+			`CREATE TABLE "sql_group" (`,
+			`CREATE TABLE "sql_user_groups" (`,
+			`  "sql_user_id" BIGINT NOT NULL,`,
+			`  "sql_group_id" TEXT NOT NULL,`,
+			`  PRIMARY KEY ("sql_user_id", "sql_group_id")`,
+			`ALTER TABLE "sql_user" ADD CONSTRAINT "fk_sql_user_profile_id" FOREIGN KEY ("profile_id") REFERENCES "sql_profile" ("id");`,
+			`ALTER TABLE "sql_user" ADD CONSTRAINT "fk_sql_user_manager_id" FOREIGN KEY ("manager_id") REFERENCES "sql_profile" ("id");`,
+			`ALTER TABLE "sql_user_groups" ADD CONSTRAINT "fk_sql_user_groups_sql_group_id" FOREIGN KEY ("sql_group_id") REFERENCES "sql_group" ("id");`,
+		}
+		for _, line := range expected {
+			if !strings.Contains(ddl, line+"\n") {
+				t.Errorf("expected line %q in:\n%s", line, ddl)
+			}
+		}
+
+		if strings.Contains(ddl, "secret") || strings.Contains(ddl, `"groups"`) {
+			t.Errorf("expected excluded and collection fields to have no column:\n%s", ddl)
+		}
+		if strings.Contains(ddl, `CREATE TABLE "sql_base"`) {
+			t.Errorf("expected embedded struct to be inlined:\n%s", ddl)
+		}
+		if strings.Index(ddl, "ALTER TABLE") < strings.LastIndex(ddl, "CREATE TABLE") {
+			t.Error("expected constraints after all tables")
+		}
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		ddl, err := GenerateSQL[SQLUser](SQLMySQL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, line := range []string{
+			"CREATE TABLE `sql_user` (",
+			"  `created_at` DATETIME NOT NULL,",
+			"  `email` VARCHAR(255) NOT NULL,",
+			"  `settings` JSON,",
+			"  `score` BIGINT UNSIGNED NOT NULL,",
+		} {
+			if !strings.Contains(ddl, line+"\n") {
+				t.Errorf("expected line %q in:\n%s", line, ddl)
+			}
+		}
+	})
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"CreatedAt":  "created_at",
+		"Address2":   "address2",
+		"name":       "name",
+	}
+	for input, expected := range tests {
+		if got := snakeCase(input); got != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
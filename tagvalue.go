@@ -0,0 +1,93 @@
+package sentinel
+
+import "strings"
+
+// TagValue is a struct tag value split into a name and its options.
+//
+// Most tags follow the encoding/json convention, where the first
+// comma-separated element is a name and the rest are options:
+// `json:"email,omitempty"` has Name "email" and Options ["omitempty"].
+// Rule-list tags such as validate have no name, so every element is an option:
+// `validate:"required,min=1,max=100"` has an empty Name and three Options.
+type TagValue struct {
+	Name    string
+	Options []string
+}
+
+// ruleListTags are the tags whose values are lists of rules without a name.
+var ruleListTags = map[string]bool{
+	"validate": true,
+}
+
+// Tag returns the named tag of a field parsed into a TagValue. The bool is
+// false if the field does not carry the tag. Commas inside single- or
+// double-quoted sections of the value do not split it, so rules such as
+// `validate:"oneof='a,b' 'c'"` stay intact, and empty options are dropped.
+//
+// Parsing is purely syntactic: `json:"-"` has Name "-" and no options.
+func (f FieldMetadata) Tag(name string) (TagValue, bool) {
+	value, ok := f.Tags[name]
+	if !ok {
+		return TagValue{}, false
+	}
+	return parseTagValue(value, ruleListTags[name]), true
+}
+
+// Has reports whether the tag carries the given option, either bare or as the
+// key of a key=value option.
+func (v TagValue) Has(option string) bool {
+	_, ok := v.Option(option)
+	return ok
+}
+
+// Option returns the value of a key=value option, or "" for a bare option.
+// The bool is false if the option is absent.
+func (v TagValue) Option(key string) (string, bool) {
+	for _, option := range v.Options {
+		k, value, _ := strings.Cut(option, "=")
+		if k == key {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// parseTagValue splits a tag value on commas outside quotes. Unless the tag is
+// a rule list, the first element is the name.
+func parseTagValue(value string, ruleList bool) TagValue {
+	parts := splitTagValue(value)
+
+	var tv TagValue
+	if !ruleList {
+		tv.Name, parts = parts[0], parts[1:]
+	}
+	for _, part := range parts {
+		if part != "" {
+			tv.Options = append(tv.Options, part)
+		}
+	}
+	return tv
+}
+
+// splitTagValue splits a tag value on commas that are not inside single- or
+// double-quoted sections. It always returns at least one element.
+func splitTagValue(value string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
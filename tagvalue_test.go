@@ -0,0 +1,79 @@
+package sentinel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldMetadataTag(t *testing.T) {
+	field := func(tags map[string]string) FieldMetadata {
+		return FieldMetadata{Name: "Field", Tags: tags}
+	}
+
+	tests := []struct {
+		name     string
+		tag      string
+		value    string
+		expected TagValue
+	}{
+		{"name only", "json", "email", TagValue{Name: "email"}},
+		{"name and options", "json", "email,omitempty,string", TagValue{Name: "email", Options: []string{"omitempty", "string"}}},
+		{"excluded", "json", "-", TagValue{Name: "-"}},
+		{"empty name", "json", ",omitempty", TagValue{Options: []string{"omitempty"}}},
+		{"db column with options", "db", "user_id,pk", TagValue{Name: "user_id", Options: []string{"pk"}}},
+		{"rule list", "validate", "required,min=1,max=100", TagValue{Options: []string{"required", "min=1", "max=100"}}},
+		{"quoted commas", "validate", "oneof='a,b' 'c',required", TagValue{Options: []string{"oneof='a,b' 'c'", "required"}}},
+		{"double-quoted commas", "desc", `"Smith, John",short`, TagValue{Name: `"Smith, John"`, Options: []string{"short"}}},
+		{"empty options dropped", "json", "name,,omitempty,", TagValue{Name: "name", Options: []string{"omitempty"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := field(map[string]string{tt.tag: tt.value}).Tag(tt.tag)
+			if !ok {
+				t.Fatal("expected tag to be present")
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("missing tag", func(t *testing.T) {
+		if _, ok := field(nil).Tag("json"); ok {
+			t.Error("expected missing tag to report false")
+		}
+	})
+
+	t.Run("option lookup", func(t *testing.T) {
+		rules, _ := field(map[string]string{"validate": "required,max=100"}).Tag("validate")
+
+		if value, ok := rules.Option("max"); !ok || value != "100" {
+			t.Errorf("expected max=100, got %q, %v", value, ok)
+		}
+		if value, ok := rules.Option("required"); !ok || value != "" {
+			t.Errorf("expected bare required, got %q, %v", value, ok)
+		}
+		if !rules.Has("max") || rules.Has("min") {
+			t.Error("unexpected Has result")
+		}
+	})
+
+	t.Run("extracted field", func(t *testing.T) {
+		type Tagged struct {
+			Email string `json:"email,omitempty" validate:"required,email"`
+		}
+
+		s := &Sentinel{registeredTags: make(map[string]bool)}
+		fields := s.extractFieldMetadata(reflect.TypeOf(Tagged{}))
+
+		jsonTag, _ := fields[0].Tag("json")
+		if jsonTag.Name != "email" || !jsonTag.Has("omitempty") {
+			t.Errorf("unexpected json tag: %+v", jsonTag)
+		}
+		rules, _ := fields[0].Tag("validate")
+		if !rules.Has("required") || !rules.Has("email") {
+			t.Errorf("unexpected validate tag: %+v", rules)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// Package sentinel holds the doc comments of the source comment fixtures
+// declared in source_test.go, which SetSourceComments reads from here since
+// test files are skipped.
+package sentinel
+
+// DocumentedBase is embedded by DocumentedWidget.
+type DocumentedBase struct {
+	// ID identifies the widget.
+	ID string
+}
+
+// DocumentedWidget is a fixture with doc comments.
+type DocumentedWidget struct {
+	DocumentedBase
+	// Name is the display name.
+	Name  string
+	Count int // Count is a trailing comment.
+	Plain bool
+}
+
+// DocumentedLegacy has fields deprecated in their doc comments.
+type DocumentedLegacy struct {
+	// Old was the first name field.
+	//
+	// Deprecated: use
+	// New instead.
+	Old string
+	// Tagged is deprecated by its tag.
+	//
+	// Deprecated: ignored.
+	Tagged string `deprecated:"use the tag note"`
+	New    string
+}
+
+type (
+	// DocumentedGrouped is declared in a type group.
+	DocumentedGrouped struct {
+		A, B string // A and B share a comment.
+	}
+)
@@ -0,0 +1,3 @@
+module github.com/zoobz-io/sentinel
+
+go 1.24
@@ -0,0 +1,9 @@
+//go:build sentinel_excluded
+
+package sentinel
+
+// DocumentedWidget is excluded by its build constraint.
+type DocumentedWidget struct {
+	// Name is excluded by its build constraint.
+	Name string
+}
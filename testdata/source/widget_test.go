@@ -0,0 +1,7 @@
+package sentinel
+
+// DocumentedWidget is redeclared in a test file.
+type DocumentedWidget struct {
+	// Name is redeclared in a test file.
+	Name string
+}
@@ -1,6 +1,7 @@
 package benchmarks
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -79,3 +80,48 @@ func BenchmarkInspectMemory(b *testing.B) {
 		_ = sentinel.Inspect[BenchmarkStruct]()
 	}
 }
+
+// largeCache builds a cache with n synthetic entries.
+func largeCache(n int) *sentinel.Cache {
+	cache := sentinel.NewCache()
+	for i := 0; i < n; i++ {
+		fqdn := fmt.Sprintf("example.com/models.Type%d", i)
+		cache.Set(fqdn, sentinel.Metadata{
+			FQDN:     fqdn,
+			TypeName: fmt.Sprintf("Type%d", i),
+			Fields: []sentinel.FieldMetadata{
+				{Name: "ID", Type: "string", Kind: sentinel.KindScalar},
+			},
+		})
+	}
+	return cache
+}
+
+func BenchmarkCacheIterationForEach(b *testing.B) {
+	cache := largeCache(5000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fields := 0
+		cache.ForEach(func(_ string, metadata sentinel.Metadata) bool {
+			fields += len(metadata.Fields)
+			return true
+		})
+	}
+}
+
+func BenchmarkCacheIterationKeysGet(b *testing.B) {
+	cache := largeCache(5000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fields := 0
+		for _, key := range cache.Keys() {
+			if metadata, ok := cache.Get(key); ok {
+				fields += len(metadata.Fields)
+			}
+		}
+	}
+}
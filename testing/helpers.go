@@ -4,6 +4,10 @@
 package testing
 
 import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/zoobz-io/sentinel"
@@ -80,6 +84,56 @@ func AssertNotCached(t testing.TB, typeName string) {
 	}
 }
 
+// AssertAllFieldsTagged verifies that every field of every cached type whose
+// package matches pkgGlob carries the given tag. The glob uses path.Match syntax
+// against the package path. Exceptions are given as "Type.Field" strings.
+// Only cached types are checked, so Scan a root type before calling this.
+// Each failure names the field and its declaration position.
+func AssertAllFieldsTagged(t testing.TB, pkgGlob, tag string, exceptions ...string) {
+	t.Helper()
+
+	skip := make(map[string]bool, len(exceptions))
+	for _, e := range exceptions {
+		skip[e] = true
+	}
+
+	schema := sentinel.Schema()
+	fqdns := make([]string, 0, len(schema))
+	for fqdn := range schema {
+		fqdns = append(fqdns, fqdn)
+	}
+	sort.Strings(fqdns)
+
+	for _, fqdn := range fqdns {
+		meta := schema[fqdn]
+		if matched, err := path.Match(pkgGlob, meta.PackageName); err != nil || !matched {
+			continue
+		}
+		for _, f := range meta.Fields {
+			if skip[meta.TypeName+"."+f.Name] {
+				continue
+			}
+			if _, ok := f.Tags[tag]; !ok {
+				t.Errorf("expected tag %q on field %s.%s (%s of %s)", tag, meta.TypeName, f.Name, fieldPosition(f), fqdn)
+			}
+		}
+	}
+}
+
+// fieldPosition describes where a field is declared: its index path in the
+// struct, with promoted fields as "outer.inner", or "virtual field" for
+// registered virtual fields, which have no declaration.
+func fieldPosition(f sentinel.FieldMetadata) string {
+	if len(f.Index) == 0 {
+		return "virtual field"
+	}
+	position := make([]string, len(f.Index))
+	for i, index := range f.Index {
+		position[i] = strconv.Itoa(index)
+	}
+	return "field " + strings.Join(position, ".")
+}
+
 // ResetCache clears the sentinel cache for test isolation.
 func ResetCache(t testing.TB) {
 	t.Helper()
@@ -3,6 +3,8 @@
 package testing
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/zoobz-io/sentinel"
@@ -23,8 +25,8 @@ func (m *mockT) Error(_ ...any) {
 	m.errors = append(m.errors, "error")
 }
 
-func (m *mockT) Errorf(format string, _ ...any) {
-	m.errors = append(m.errors, format)
+func (m *mockT) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
 }
 
 func (m *mockT) failed() bool {
@@ -41,6 +43,18 @@ type HelperRefStruct struct {
 	Value string
 }
 
+type TaggedFixture struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Missing string
+}
+
+type EmbeddingFixture struct {
+	Code string `json:"code"`
+	note string //nolint:unused // Listed with SetUnexportedFields
+	TaggedFixture
+}
+
 func TestAssertMetadataValid(t *testing.T) {
 	ResetCache(t)
 	meta := sentinel.Inspect[HelperTestStruct]()
@@ -189,6 +203,72 @@ func TestAssertNotCached(t *testing.T) {
 	})
 }
 
+func TestAssertAllFieldsTagged(t *testing.T) {
+	ResetCache(t)
+	sentinel.Inspect[TaggedFixture]()
+	pkg := "github.com/zoobz-io/sentinel/*"
+
+	t.Run("reports untagged fields", func(t *testing.T) {
+		mock := &mockT{}
+		AssertAllFieldsTagged(mock, pkg, "json")
+		if len(mock.errors) != 1 {
+			t.Fatalf("expected 1 failure, got %d: %v", len(mock.errors), mock.errors)
+		}
+		if !strings.Contains(mock.errors[0], "TaggedFixture.Missing (field 2 of") {
+			t.Errorf("unexpected failure message: %s", mock.errors[0])
+		}
+	})
+
+	t.Run("honors exceptions", func(t *testing.T) {
+		mock := &mockT{}
+		AssertAllFieldsTagged(mock, pkg, "json", "TaggedFixture.Missing")
+		if mock.failed() {
+			t.Errorf("expected no failures, got %v", mock.errors)
+		}
+	})
+
+	t.Run("ignores packages outside the glob", func(t *testing.T) {
+		mock := &mockT{}
+		AssertAllFieldsTagged(mock, "example.com/*", "json")
+		if mock.failed() {
+			t.Errorf("expected no failures, got %v", mock.errors)
+		}
+	})
+
+	t.Run("reports virtual fields", func(t *testing.T) {
+		sentinel.RegisterVirtualField[TaggedFixture](sentinel.FieldMetadata{Name: "Computed", Type: "string"})
+
+		mock := &mockT{}
+		AssertAllFieldsTagged(mock, pkg, "json", "TaggedFixture.Missing")
+		if len(mock.errors) != 1 {
+			t.Fatalf("expected 1 failure, got %d: %v", len(mock.errors), mock.errors)
+		}
+		if !strings.Contains(mock.errors[0], "TaggedFixture.Computed (virtual field of") {
+			t.Errorf("unexpected failure message: %s", mock.errors[0])
+		}
+	})
+
+	t.Run("reports declaration positions", func(t *testing.T) {
+		sentinel.SetUnexportedFields(true)
+		defer sentinel.SetUnexportedFields(false)
+		sentinel.SetFlattenEmbedded(true)
+		defer sentinel.SetFlattenEmbedded(false)
+		sentinel.Inspect[EmbeddingFixture]()
+
+		mock := &mockT{}
+		AssertAllFieldsTagged(mock, pkg, "json", "TaggedFixture.Missing", "TaggedFixture.Computed", "EmbeddingFixture.TaggedFixture")
+		if len(mock.errors) != 2 {
+			t.Fatalf("expected 2 failures, got %d: %v", len(mock.errors), mock.errors)
+		}
+		failures := strings.Join(mock.errors, "\n")
+		for _, expected := range []string{"EmbeddingFixture.note (field 1 of", "EmbeddingFixture.Missing (field 2.2 of"} {
+			if !strings.Contains(failures, expected) {
+				t.Errorf("expected a failure containing %q, got %v", expected, mock.errors)
+			}
+		}
+	})
+}
+
 func TestResetCache(t *testing.T) {
 	sentinel.Inspect[HelperTestStruct]()
 
@@ -516,6 +516,22 @@ func TestPointerVariations(t *testing.T) {
 			t.Errorf("expected 'map', got %s", rel.Kind)
 		}
 	})
+
+	t.Run("via pointer flag", func(t *testing.T) {
+		expected := map[string]bool{
+			"Direct":   false,
+			"Pointer":  true,
+			"Slice":    false,
+			"PtrSlice": true,
+			"Map":      false,
+			"PtrMap":   true,
+		}
+		for field, viaPointer := range expected {
+			if rel := relMap[field]; rel.ViaPointer != viaPointer {
+				t.Errorf("expected %s ViaPointer=%v, got %v", field, viaPointer, rel.ViaPointer)
+			}
+		}
+	})
 }
 
 func TestFieldMetadataAccuracy(t *testing.T) {
@@ -0,0 +1,64 @@
+package sentinel
+
+import (
+	"reflect"
+	"slices"
+)
+
+// RegisterVirtualField adds a field that is not declared on T, such as a
+// property computed at serialization time. The field is flagged Virtual and
+// appended to T's Fields, after the declared fields, in every extraction and in
+// any metadata already cached, so generators and exports include it. Virtual
+// fields have no ReflectType or Index unless provided, and are skipped by
+// structural operations such as BuildReflectType and StructurallyCompatible.
+// Registrations persist until Reset.
+// Panics if T is not a struct type.
+func RegisterVirtualField[T any](field FieldMetadata) {
+	var zero T
+	t, err := structType(reflect.TypeOf(zero))
+	if err != nil {
+		panic(err)
+	}
+
+	field.Virtual = true
+	field.Exported = true
+	jsonName, included := codecFieldName(field, "json")
+	field.JSONName, field.JSONOmitted = jsonName, !included
+	field.SerializedNames = serializedNames(field, instance.codecs())
+	fqdn := getFQDN(t)
+
+	instance.configMutex.Lock()
+	if instance.virtualFields == nil {
+		instance.virtualFields = make(map[string][]FieldMetadata)
+	}
+	instance.virtualFields[fqdn] = append(instance.virtualFields[fqdn], field)
+	instance.configMutex.Unlock()
+
+	// Update metadata that was cached before the registration
+	instance.cache.Update(fqdn, instance.withVirtualFields)
+}
+
+// storeMetadata caches metadata, then brings its virtual fields up to date
+// under the cache lock. An extraction that read the registry before a
+// concurrent RegisterVirtualField therefore cannot drop the new field: either
+// this update or the registration's own runs last and sees it.
+func (s *Sentinel) storeMetadata(fqdn string, metadata Metadata) {
+	s.cache.Set(fqdn, metadata)
+	s.cache.Update(fqdn, s.withVirtualFields)
+}
+
+// withVirtualFields returns metadata with its virtual fields replaced by those
+// currently registered for the type.
+func (s *Sentinel) withVirtualFields(metadata Metadata) Metadata {
+	metadata.Fields = append(declaredFields(metadata.Fields), s.virtualFieldsFor(metadata.FQDN)...)
+	metadata.FieldGroups = groupFields(metadata.Fields)
+	return metadata
+}
+
+// virtualFieldsFor returns the virtual fields registered for a type.
+func (s *Sentinel) virtualFieldsFor(fqdn string) []FieldMetadata {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return slices.Clone(s.virtualFields[fqdn])
+}
@@ -0,0 +1,138 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type VirtualPerson struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+type VirtualCached struct {
+	ID string `json:"id"`
+}
+
+func TestRegisterVirtualField(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterVirtualField[VirtualPerson](FieldMetadata{
+		Name: "FullName",
+		Type: "string",
+		Kind: KindScalar,
+		Tags: map[string]string{"json": "full_name", "desc": "First and last name"},
+	})
+
+	metadata := Inspect[VirtualPerson]()
+
+	t.Run("appended and flagged", func(t *testing.T) {
+		if len(metadata.Fields) != 3 {
+			t.Fatalf("expected 3 fields, got %d", len(metadata.Fields))
+		}
+		virtual := metadata.Fields[2]
+		if virtual.Name != "FullName" || !virtual.Virtual {
+			t.Errorf("expected virtual FullName field last, got %+v", virtual)
+		}
+		for _, field := range metadata.Fields[:2] {
+			if field.Virtual {
+				t.Errorf("expected declared field %s not to be virtual", field.Name)
+			}
+		}
+	})
+
+	t.Run("included in generated schema", func(t *testing.T) {
+		markdown := GenerateMarkdown()
+		if !strings.Contains(markdown, "FullName") || !strings.Contains(markdown, "First and last name") {
+			t.Error("expected virtual field in generated markdown")
+		}
+
+		var buf strings.Builder
+		if err := ExportCompactSchema(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"json_name": "full_name"`) {
+			t.Error("expected virtual field in compact schema")
+		}
+	})
+
+	t.Run("skipped structurally", func(t *testing.T) {
+		rebuilt, err := metadata.BuildReflectType()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rebuilt.NumField() != 2 {
+			t.Errorf("expected virtual field to be skipped, got %d fields", rebuilt.NumField())
+		}
+		if _, changed := ScanIfChanged[VirtualPerson](); changed {
+			t.Error("expected virtual fields not to affect the fingerprint")
+		}
+	})
+
+	t.Run("already cached type", func(t *testing.T) {
+		Inspect[VirtualCached]()
+		RegisterVirtualField[VirtualCached](FieldMetadata{Name: "URL", Type: "string", Kind: KindScalar})
+
+		cached := Inspect[VirtualCached]()
+		if len(cached.Fields) != 2 || !cached.Fields[1].Virtual {
+			t.Errorf("expected cached metadata to gain the virtual field, got %+v", cached.Fields)
+		}
+	})
+
+	t.Run("survives cache clear until reset", func(t *testing.T) {
+		instance.cache.Clear()
+		if fields := Inspect[VirtualPerson]().Fields; len(fields) != 3 {
+			t.Errorf("expected virtual field after re-extraction, got %d fields", len(fields))
+		}
+
+		Reset()
+		if fields := Inspect[VirtualPerson]().Fields; len(fields) != 2 {
+			t.Errorf("expected virtual field to be cleared by Reset, got %d fields", len(fields))
+		}
+	})
+}
+
+type VirtualConcurrent struct {
+	ID string `json:"id"`
+}
+
+func TestRegisterVirtualFieldConcurrent(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	const registrations = 50
+
+	// Extractions race each registration; none may drop a registered field
+	var wg sync.WaitGroup
+	for i := 0; i < registrations; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterVirtualField[VirtualConcurrent](FieldMetadata{Name: fmt.Sprintf("V%d", i), Type: "string"})
+		}()
+		go func() {
+			defer wg.Done()
+			Forget(getFQDN(reflect.TypeOf(VirtualConcurrent{})))
+			Inspect[VirtualConcurrent]()
+		}()
+	}
+	wg.Wait()
+
+	metadata := Inspect[VirtualConcurrent]()
+	if len(metadata.Fields) != 1+registrations {
+		t.Fatalf("expected %d fields, got %d", 1+registrations, len(metadata.Fields))
+	}
+	seen := make(map[string]bool, registrations)
+	for _, field := range metadata.Fields[1:] {
+		if !field.Virtual || seen[field.Name] {
+			t.Errorf("expected distinct virtual fields after ID, got %+v", field)
+		}
+		seen[field.Name] = true
+	}
+}
@@ -0,0 +1,62 @@
+package sentinel
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// visitedSet tracks the types already processed during a recursive scan.
+// It is backed by a sync.Map so the same set can be shared safely by
+// goroutines walking overlapping parts of one type graph.
+// An optional progress callback is notified as the scan caches new types,
+// and warnings raised during the scan are collected for the root type.
+type visitedSet struct {
+	seen       sync.Map
+	progress   func(discovered string, total int)
+	warnings   []string
+	cached     atomic.Int64
+	warningsMu sync.Mutex
+}
+
+// newVisitedSet creates an empty visited set.
+func newVisitedSet() *visitedSet {
+	return &visitedSet{}
+}
+
+// Visit marks a type as visited.
+// Returns true if the type had not been visited before.
+func (v *visitedSet) Visit(fqdn string) bool {
+	_, loaded := v.seen.LoadOrStore(fqdn, struct{}{})
+	return !loaded
+}
+
+// Has reports whether a type has been visited.
+func (v *visitedSet) Has(fqdn string) bool {
+	_, ok := v.seen.Load(fqdn)
+	return ok
+}
+
+// Cached records that a newly extracted type was stored in the cache and
+// notifies the progress callback, if any, with the running total.
+func (v *visitedSet) Cached(fqdn string) {
+	if v.progress == nil {
+		return
+	}
+	v.progress(fqdn, int(v.cached.Add(1)))
+}
+
+// Warn records a warning raised during the scan.
+func (v *visitedSet) Warn(warning string) {
+	v.warningsMu.Lock()
+	defer v.warningsMu.Unlock()
+
+	v.warnings = append(v.warnings, warning)
+}
+
+// Warnings returns the warnings recorded so far.
+func (v *visitedSet) Warnings() []string {
+	v.warningsMu.Lock()
+	defer v.warningsMu.Unlock()
+
+	return append([]string(nil), v.warnings...)
+}
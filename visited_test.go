@@ -0,0 +1,92 @@
+//go:build testing
+
+package sentinel
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestVisitedSet(t *testing.T) {
+	t.Run("visit marks once", func(t *testing.T) {
+		v := newVisitedSet()
+
+		if v.Has("pkg.Type") {
+			t.Error("expected empty set")
+		}
+		if !v.Visit("pkg.Type") {
+			t.Error("expected first visit to return true")
+		}
+		if v.Visit("pkg.Type") {
+			t.Error("expected second visit to return false")
+		}
+		if !v.Has("pkg.Type") {
+			t.Error("expected type to be visited")
+		}
+	})
+
+	t.Run("concurrent visits admit exactly one winner", func(t *testing.T) {
+		v := newVisitedSet()
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		winners := 0
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if v.Visit("pkg.Shared") {
+					mu.Lock()
+					winners++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if winners != 1 {
+			t.Errorf("expected exactly 1 winning visit, got %d", winners)
+		}
+	})
+
+	t.Run("concurrent scans share one set", func(t *testing.T) {
+		instance.cache.Clear()
+
+		visited := newVisitedSet()
+		roots := []reflect.Type{
+			reflect.TypeOf(User{}),
+			reflect.TypeOf(Profile{}),
+			reflect.TypeOf(Order{}),
+			reflect.TypeOf(Settings{}),
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			for _, root := range roots {
+				wg.Add(1)
+				go func(typ reflect.Type) {
+					defer wg.Done()
+					instance.scanWithVisited(typ, visited)
+				}(root)
+			}
+		}
+		wg.Wait()
+
+		for _, typ := range []reflect.Type{
+			reflect.TypeOf(User{}),
+			reflect.TypeOf(Profile{}),
+			reflect.TypeOf(Address{}),
+			reflect.TypeOf(Order{}),
+			reflect.TypeOf(OrderItem{}),
+			reflect.TypeOf(Settings{}),
+			reflect.TypeOf(Data{}),
+		} {
+			fqdn := getFQDN(typ)
+			if !visited.Has(fqdn) {
+				t.Errorf("expected %s to be visited", fqdn)
+			}
+		}
+	})
+}